@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/logan-bobo/pokedex-cli/internal/pokeapi"
+)
+
+const maxSuggestions = 3
+
+func lookupError(ctx context.Context, client *pokeapi.Client, err error, resource string, name string) error {
+	var httpErr *pokeapi.HTTPError
+	var decodeErr *pokeapi.DecodeError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return err
+	case errors.Is(err, pokeapi.ErrNoEvolutionChain):
+		return errors.New(fmt.Sprintf("%v has no evolution chain", name))
+	case errors.Is(err, pokeapi.ErrNotFound):
+		if name == "" {
+			return errors.New(fmt.Sprintf("Please provide a %v name", resource))
+		}
+
+		names, namesErr := client.GetNamesContext(ctx, resource)
+
+		if namesErr != nil {
+			return errors.New(fmt.Sprintf("No %v named %v", resource, name))
+		}
+
+		suggestions := suggest(name, names)
+
+		if len(suggestions) == 0 {
+			return errors.New(fmt.Sprintf("No %v named %v", resource, name))
+		}
+
+		return errors.New(fmt.Sprintf("No %v named %v, did you mean: %v?", resource, name, strings.Join(suggestions, ", ")))
+	case errors.As(err, &httpErr):
+		return errors.New(fmt.Sprintf("PokeAPI returned status %v for %v %v, try again later", httpErr.Status, resource, name))
+	case errors.As(err, &decodeErr):
+		return errors.New(fmt.Sprintf("PokeAPI sent %v data that could not be read: %v", decodeErr.Resource, decodeErr.Err))
+	case errors.Is(err, context.DeadlineExceeded):
+		return errors.New("PokeAPI took too long to respond, try again later")
+	default:
+		return errors.New(fmt.Sprintf("Unable to reach PokeAPI: %v", err))
+	}
+}
+
+func suggest(name string, candidates []string) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+
+	limit := max(2, len(name)/3)
+	matches := []match{}
+
+	for _, candidate := range candidates {
+		distance := levenshtein(name, candidate)
+
+		if strings.HasPrefix(candidate, name) {
+			distance = min(distance, 1)
+		}
+
+		if distance <= limit {
+			matches = append(matches, match{name: candidate, distance: distance})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	suggestions := []string{}
+
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, matches[i].name)
+	}
+
+	return suggestions
+}
+
+func levenshtein(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
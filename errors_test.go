@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/logan-bobo/pokedex-cli/internal/pokeapi"
+)
+
+func TestSuggest(t *testing.T) {
+	cases := []struct {
+		name       string
+		input      string
+		candidates []string
+		expected   []string
+	}{
+		{
+			name:       "typo",
+			input:      "pikachoo",
+			candidates: []string{"pikachu", "raichu", "pichu"},
+			expected:   []string{"pikachu"},
+		},
+		{
+			name:       "prefix",
+			input:      "char",
+			candidates: []string{"charmander", "charmeleon", "squirtle"},
+			expected:   []string{"charmander", "charmeleon"},
+		},
+		{
+			name:       "closest first",
+			input:      "eeve",
+			candidates: []string{"evee", "eevee", "steelix"},
+			expected:   []string{"eevee", "evee"},
+		},
+		{
+			name:       "outside the distance limit",
+			input:      "mew",
+			candidates: []string{"bulbasaur", "snorlax"},
+			expected:   []string{},
+		},
+		{
+			name:       "at most three",
+			input:      "pika",
+			candidates: []string{"pikachu", "pikachu-rock-star", "pikachu-belle", "pikachu-pop-star"},
+			expected:   []string{"pikachu", "pikachu-rock-star", "pikachu-belle"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := suggest(testCase.input, testCase.candidates); !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("Suggestions did not match. Got %v wanted %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	cases := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{a: "", b: "", expected: 0},
+		{a: "mew", b: "", expected: 3},
+		{a: "kitten", b: "sitting", expected: 3},
+		{a: "eevee", b: "evee", expected: 1},
+	}
+
+	for _, testCase := range cases {
+		if got := levenshtein(testCase.a, testCase.b); got != testCase.expected {
+			t.Errorf("Distance between %v and %v did not match. Got %v wanted %v", testCase.a, testCase.b, got, testCase.expected)
+		}
+	}
+}
+
+func TestLookupError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pokemon/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write([]byte(`{"results":[{"name":"pikachu"},{"name":"pichu"},{"name":"raichu"}]}`))
+	}))
+	defer server.Close()
+
+	client := pokeapi.NewClient(pokeapi.WithBaseURL(server.URL))
+
+	cases := []struct {
+		name     string
+		err      error
+		resource string
+		input    string
+		expected string
+	}{
+		{
+			name:     "not found with suggestions",
+			err:      pokeapi.ErrNotFound,
+			resource: "pokemon",
+			input:    "pikachoo",
+			expected: "No pokemon named pikachoo, did you mean: pikachu?",
+		},
+		{
+			name:     "not found without a name list",
+			err:      pokeapi.ErrNotFound,
+			resource: "move",
+			input:    "thunderbolt",
+			expected: "No move named thunderbolt",
+		},
+		{
+			name:     "missing name",
+			err:      pokeapi.ErrNotFound,
+			resource: "pokemon",
+			expected: "Please provide a pokemon name",
+		},
+		{
+			name:     "server error",
+			err:      &pokeapi.HTTPError{Status: http.StatusServiceUnavailable},
+			resource: "pokemon",
+			input:    "pikachu",
+			expected: "PokeAPI returned status 503 for pokemon pikachu, try again later",
+		},
+		{
+			name:     "no evolution chain",
+			err:      pokeapi.ErrNoEvolutionChain,
+			resource: "pokemon-species",
+			input:    "ditto",
+			expected: "ditto has no evolution chain",
+		},
+		{
+			name:     "timeout",
+			err:      context.DeadlineExceeded,
+			resource: "pokemon",
+			input:    "pikachu",
+			expected: "PokeAPI took too long to respond, try again later",
+		},
+		{
+			name:     "transport error",
+			err:      errors.New("connection refused"),
+			resource: "pokemon",
+			input:    "pikachu",
+			expected: "Unable to reach PokeAPI: connection refused",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := lookupError(context.Background(), client, testCase.err, testCase.resource, testCase.input)
+
+			if got.Error() != testCase.expected {
+				t.Errorf("Error did not match. Got %v wanted %v", got, testCase.expected)
+			}
+		})
+	}
+
+	if err := lookupError(context.Background(), client, context.Canceled, "pokemon", "pikachu"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancellation to pass through, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+type fakeTicker struct {
+	c       chan time.Time
+	period  time.Duration
+	next    time.Time
+	stopped bool
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+func (f *fakeClock) NewTicker(d time.Duration) Ticker {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ticker := &fakeTicker{
+		c:      make(chan time.Time, 1),
+		period: d,
+		next:   f.now.Add(d),
+	}
+
+	f.tickers = append(f.tickers, ticker)
+
+	return &fakeTickerHandle{clock: f, ticker: ticker}
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = f.now.Add(d)
+
+	for _, ticker := range f.tickers {
+		if ticker.stopped || ticker.next.After(f.now) {
+			continue
+		}
+
+		select {
+		case ticker.c <- f.now:
+		default:
+		}
+
+		for !ticker.next.After(f.now) {
+			ticker.next = ticker.next.Add(ticker.period)
+		}
+	}
+}
+
+type fakeTickerHandle struct {
+	clock  *fakeClock
+	ticker *fakeTicker
+}
+
+func (h *fakeTickerHandle) Chan() <-chan time.Time {
+	return h.ticker.c
+}
+
+func (h *fakeTickerHandle) Stop() {
+	h.clock.mu.Lock()
+	defer h.clock.mu.Unlock()
+
+	h.ticker.stopped = true
+}
+
+func eventually(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Condition not met before deadline")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
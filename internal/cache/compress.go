@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"hash/crc32"
+	"io"
+)
+
+func compress(val []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	if _, err := w.Write(val); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decompress(val []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(val))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+func maybeCompress(val []byte, threshold int) ([]byte, bool) {
+	if threshold <= 0 || len(val) < threshold {
+		return val, false
+	}
+
+	compressed, err := compress(val)
+
+	if err != nil || len(compressed) >= len(val) {
+		return val, false
+	}
+
+	return compressed, true
+}
+
+func checksum(val []byte) uint32 {
+	return crc32.ChecksumIEEE(val)
+}
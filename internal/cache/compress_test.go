@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheCompression(t *testing.T) {
+	large := []byte(strings.Repeat(`{"name":"pikachu","url":"https://pokeapi.co/api/v2/pokemon/25/"}`, 100))
+
+	cases := []struct {
+		name       string
+		threshold  int
+		val        []byte
+		compressed bool
+	}{
+		{
+			name:       "above threshold",
+			threshold:  1024,
+			val:        large,
+			compressed: true,
+		},
+		{
+			name:       "below threshold",
+			threshold:  len(large) + 1,
+			val:        large,
+			compressed: false,
+		},
+		{
+			name:       "disabled",
+			threshold:  0,
+			val:        large,
+			compressed: false,
+		},
+		{
+			name:       "incompressible",
+			threshold:  1,
+			val:        []byte("x"),
+			compressed: false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cache := NewCache(time.Minute, WithCompression(testCase.threshold))
+			defer cache.Close()
+
+			cache.Add("www.example.com", testCase.val)
+
+			data, ok := cache.Get("www.example.com")
+
+			if !ok || string(data) != string(testCase.val) {
+				t.Fatalf("Expected the original value back")
+			}
+
+			stats := cache.Stats()
+
+			if compressed := stats.BytesSaved > 0; compressed != testCase.compressed {
+				t.Errorf("Expected compressed to be %v, saved %v bytes", testCase.compressed, stats.BytesSaved)
+			}
+
+			if testCase.compressed && stats.Bytes+stats.BytesSaved != len("www.example.com")+len(testCase.val) {
+				t.Errorf("Byte accounting did not add up. Got %v stored and %v saved", stats.Bytes, stats.BytesSaved)
+			}
+
+			cache.Delete("www.example.com")
+
+			if stats := cache.Stats(); stats.Bytes != 0 || stats.BytesSaved != 0 {
+				t.Errorf("Expected accounting to reset. Got %+v", stats)
+			}
+		})
+	}
+}
+
+func TestDiskCompression(t *testing.T) {
+	large := []byte(strings.Repeat("somedata", 1000))
+
+	disk, err := NewDiskStore(t.TempDir(), time.Minute, WithDiskCompression(1024))
+
+	if err != nil {
+		t.Fatalf("Unable to create disk store: %v", err)
+	}
+
+	disk.Add("www.example.com", large)
+
+	data, ok := disk.Get("www.example.com")
+
+	if !ok || string(data) != string(large) {
+		t.Fatalf("Expected the original value back")
+	}
+
+	if stats := disk.Stats(); stats.Bytes >= len(large) {
+		t.Errorf("Expected the entry to be stored compressed, it uses %v bytes", stats.Bytes)
+	}
+
+	stored, _ := maybeCompress(large, 1024)
+
+	if got := disk.Stats().BytesSaved; got != len(large)-len(stored) {
+		t.Errorf("Bytes saved did not match. Got %v wanted %v", got, len(large)-len(stored))
+	}
+}
+
+func TestTypedWithCompression(t *testing.T) {
+	cache := NewCache(time.Minute, WithCompression(1))
+	defer cache.Close()
+
+	decodes := 0
+
+	typed := NewTyped[int](cache, func(raw []byte) (int, error) {
+		decodes++
+		return strconv.Atoi(strings.TrimLeft(string(raw), "0"))
+	})
+
+	cache.Add("answer", []byte(strings.Repeat("0", 100)+"42"))
+
+	for i := 0; i < 3; i++ {
+		if value, ok, err := typed.Get("answer"); !ok || err != nil || value != 42 {
+			t.Fatalf("Unexpected lookup. Got %v, %v and %v", value, ok, err)
+		}
+	}
+
+	if decodes != 1 {
+		t.Errorf("Expected one decode of the compressed entry, got %v", decodes)
+	}
+}
@@ -0,0 +1,473 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const diskEntryExt = ".json"
+
+const defaultDiskReapInterval = 10 * time.Minute
+
+type DiskStore struct {
+	dir             string
+	ttl             time.Duration
+	compressAbove   int
+	staleRevalidate time.Duration
+	staleIfError    time.Duration
+	reapInterval    time.Duration
+	clock           Clock
+	mu              sync.Mutex
+	stats           Stats
+	done            chan struct{}
+	closeOnce       sync.Once
+	reaper          sync.WaitGroup
+}
+
+type DiskOption func(*DiskStore)
+
+func WithDiskCompression(threshold int) DiskOption {
+	return func(d *DiskStore) {
+		d.compressAbove = threshold
+	}
+}
+
+func WithDiskStaleWhileRevalidate(window time.Duration) DiskOption {
+	return func(d *DiskStore) {
+		d.staleRevalidate = window
+	}
+}
+
+func WithDiskStaleIfError(window time.Duration) DiskOption {
+	return func(d *DiskStore) {
+		d.staleIfError = window
+	}
+}
+
+func WithDiskReapInterval(interval time.Duration) DiskOption {
+	return func(d *DiskStore) {
+		d.reapInterval = interval
+	}
+}
+
+func WithDiskClock(clock Clock) DiskOption {
+	return func(d *DiskStore) {
+		d.clock = clock
+	}
+}
+
+type diskEntry struct {
+	Key        string     `json:"key"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	Val        []byte     `json:"val"`
+	Validators Validators `json:"validators"`
+	Compressed bool       `json:"compressed,omitempty"`
+}
+
+type diskHeader struct {
+	Key        string     `json:"key"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	Validators Validators `json:"validators"`
+}
+
+func NewDiskStore(dir string, ttl time.Duration, opts ...DiskOption) (*DiskStore, error) {
+	err := os.MkdirAll(dir, 0o755)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d := DiskStore{dir: dir, ttl: ttl, clock: realClock{}, done: make(chan struct{})}
+
+	for _, opt := range opts {
+		opt(&d)
+	}
+
+	d.reap()
+
+	if d.reapInterval > 0 {
+		d.reaper.Add(1)
+		go d.reaploop(d.clock.NewTicker(d.reapInterval))
+	}
+
+	return &d, nil
+}
+
+func (d *DiskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+diskEntryExt)
+}
+
+func (d *DiskStore) Get(key string) ([]byte, bool) {
+	entry, err := d.read(d.path(key))
+
+	if err != nil || entry.Key != key {
+		d.count(func(stats *Stats) { stats.Misses++ })
+		return nil, false
+	}
+
+	now := d.clock.Now()
+
+	if isExpired(entry.ExpiresAt, now) {
+		d.expire(key, entry, now)
+		d.count(func(stats *Stats) { stats.Misses++ })
+
+		return nil, false
+	}
+
+	val, err := entry.value()
+
+	if err != nil {
+		d.Delete(key)
+		d.count(func(stats *Stats) { stats.Misses++ })
+
+		return nil, false
+	}
+
+	d.count(func(stats *Stats) { stats.Hits++ })
+
+	return val, true
+}
+
+func (d *DiskStore) GetStale(key string) ([]byte, Freshness, bool) {
+	entry, freshness, ok := d.GetEntry(key)
+
+	return entry.Val, freshness, ok
+}
+
+func (d *DiskStore) GetEntry(key string) (Entry, Freshness, bool) {
+	stored, err := d.read(d.path(key))
+
+	if err != nil || stored.Key != key {
+		d.count(func(stats *Stats) { stats.Misses++ })
+		return Entry{}, Fresh, false
+	}
+
+	now := d.clock.Now()
+
+	if d.dead(stored, now) {
+		d.expire(key, stored, now)
+		d.count(func(stats *Stats) { stats.Misses++ })
+
+		return Entry{}, Fresh, false
+	}
+
+	val, err := stored.value()
+
+	if err != nil {
+		d.Delete(key)
+		d.count(func(stats *Stats) { stats.Misses++ })
+
+		return Entry{}, Fresh, false
+	}
+
+	entry := Entry{
+		Val:        val,
+		TTL:        remaining(stored.ExpiresAt, now),
+		Validators: stored.Validators,
+	}
+
+	if !isExpired(stored.ExpiresAt, now) {
+		d.count(func(stats *Stats) { stats.Hits++ })
+		return entry, Fresh, true
+	}
+
+	if d.pastStale(stored, now) {
+		d.count(func(stats *Stats) { stats.Misses++ })
+		return entry, Expired, true
+	}
+
+	d.count(func(stats *Stats) { stats.StaleHits++ })
+
+	if now.Sub(stored.ExpiresAt) < d.staleRevalidate {
+		return entry, StaleWhileRevalidate, true
+	}
+
+	return entry, StaleIfError, true
+}
+
+func (d *DiskStore) dead(entry diskEntry, now time.Time) bool {
+	return d.pastStale(entry, now) && entry.Validators.Empty()
+}
+
+func (d *DiskStore) pastStale(entry diskEntry, now time.Time) bool {
+	if !isExpired(entry.ExpiresAt, now) {
+		return false
+	}
+
+	return now.Sub(entry.ExpiresAt) >= max(d.staleRevalidate, d.staleIfError)
+}
+
+func (d *DiskStore) expire(key string, entry diskEntry, now time.Time) {
+	if !d.dead(entry, now) {
+		return
+	}
+
+	d.Delete(key)
+	d.count(func(stats *Stats) { stats.Expirations++ })
+}
+
+func (d *DiskStore) Add(key string, val []byte) {
+	d.AddWithTTL(key, val, d.ttl)
+}
+
+func (d *DiskStore) AddWithTTL(key string, val []byte, ttl time.Duration) {
+	d.AddWithValidators(key, val, ttl, Validators{})
+}
+
+func (d *DiskStore) AddWithValidators(key string, val []byte, ttl time.Duration, validators Validators) {
+	now := d.clock.Now()
+
+	d.put(key, val, validators, now, expiresAt(now, ttl))
+}
+
+func (d *DiskStore) Restore(entry SnapshotEntry) {
+	d.put(entry.Key, entry.Val, entry.Validators, entry.CreatedAt, entry.expiry())
+}
+
+func (d *DiskStore) put(key string, val []byte, validators Validators, createdAt time.Time, expiresAt time.Time) {
+	stored, compressed := maybeCompress(val, d.compressAbove)
+
+	entry := diskEntry{
+		Key:        key,
+		CreatedAt:  createdAt,
+		ExpiresAt:  expiresAt,
+		Val:        stored,
+		Validators: validators,
+		Compressed: compressed,
+	}
+
+	if d.dead(entry, d.clock.Now()) {
+		return
+	}
+
+	err := d.write(entry)
+
+	if err != nil {
+		d.count(func(stats *Stats) { stats.WriteErrors++ })
+		return
+	}
+
+	if compressed {
+		d.count(func(stats *Stats) { stats.BytesSaved += len(val) - len(stored) })
+	}
+}
+
+func (d *DiskStore) GetValidators(key string) (Validators, bool) {
+	entry, err := d.read(d.path(key))
+
+	if err != nil || entry.Key != key || entry.Validators.Empty() || d.dead(entry, d.clock.Now()) {
+		return Validators{}, false
+	}
+
+	return entry.Validators, true
+}
+
+func (d *DiskStore) Delete(key string) {
+	os.Remove(d.path(key))
+}
+
+func (d *DiskStore) Stats() Stats {
+	d.mu.Lock()
+	stats := d.stats
+	d.mu.Unlock()
+
+	for _, file := range d.files() {
+		info, err := file.Info()
+
+		if err != nil {
+			continue
+		}
+
+		stats.Entries++
+		stats.Bytes += int(info.Size())
+	}
+
+	return stats
+}
+
+func (d *DiskStore) Keys() []string {
+	keys := []string{}
+
+	for _, file := range d.files() {
+		header, err := d.readHeader(filepath.Join(d.dir, file.Name()))
+
+		if err != nil {
+			continue
+		}
+
+		keys = append(keys, header.Key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (d *DiskStore) Snapshot() []SnapshotEntry {
+	now := d.clock.Now()
+	entries := []SnapshotEntry{}
+
+	for _, file := range d.files() {
+		entry, err := d.read(filepath.Join(d.dir, file.Name()))
+
+		if err != nil || d.dead(entry, now) {
+			continue
+		}
+
+		val, err := entry.value()
+
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, newSnapshotEntry(entry.Key, entry.CreatedAt, entry.ExpiresAt, val, entry.Validators))
+	}
+
+	return entries
+}
+
+func (d *DiskStore) files() []os.DirEntry {
+	entries, err := os.ReadDir(d.dir)
+
+	if err != nil {
+		return nil
+	}
+
+	files := []os.DirEntry{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), diskEntryExt) {
+			continue
+		}
+
+		files = append(files, entry)
+	}
+
+	return files
+}
+
+func (d *DiskStore) count(update func(stats *Stats)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	update(&d.stats)
+}
+
+func (d *DiskStore) Close() error {
+	d.closeOnce.Do(func() {
+		close(d.done)
+	})
+
+	d.reaper.Wait()
+
+	return nil
+}
+
+func (d *DiskStore) reaploop(ticker Ticker) {
+	defer d.reaper.Done()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.done:
+			return
+		case <-ticker.Chan():
+		}
+
+		d.reap()
+	}
+}
+
+func (d *DiskStore) reap() {
+	now := d.clock.Now()
+
+	for _, file := range d.files() {
+		path := filepath.Join(d.dir, file.Name())
+
+		header, err := d.readHeader(path)
+
+		if err != nil {
+			continue
+		}
+
+		if d.dead(diskEntry{ExpiresAt: header.ExpiresAt, Validators: header.Validators}, now) {
+			os.Remove(path)
+			d.count(func(stats *Stats) { stats.Expirations++ })
+		}
+	}
+}
+
+func (e diskEntry) value() ([]byte, error) {
+	if !e.Compressed {
+		return e.Val, nil
+	}
+
+	return decompress(e.Val)
+}
+
+func (d *DiskStore) read(path string) (diskEntry, error) {
+	var entry diskEntry
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return entry, err
+	}
+
+	err = json.Unmarshal(data, &entry)
+
+	if err != nil {
+		os.Remove(path)
+	}
+
+	return entry, err
+}
+
+func (d *DiskStore) readHeader(path string) (diskHeader, error) {
+	var header diskHeader
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return header, err
+	}
+
+	return header, json.Unmarshal(data, &header)
+}
+
+func (d *DiskStore) write(entry diskEntry) error {
+	data, err := json.Marshal(entry)
+
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(d.dir, "entry-*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(data)
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), d.path(entry.Key))
+}
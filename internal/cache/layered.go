@@ -0,0 +1,212 @@
+package cache
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+type Layered struct {
+	upper Store
+	lower Store
+}
+
+func NewLayered(upper Store, lower Store) *Layered {
+	return &Layered{
+		upper: upper,
+		lower: lower,
+	}
+}
+
+func NewPersistentCache(ttl time.Duration, dir string, opts ...Option) (*Layered, error) {
+	memory := NewCache(ttl, opts...)
+
+	disk, err := NewDiskStore(
+		dir,
+		ttl,
+		WithDiskCompression(memory.compressAbove),
+		WithDiskStaleWhileRevalidate(memory.staleRevalidate),
+		WithDiskStaleIfError(memory.staleIfError),
+		WithDiskClock(memory.clock),
+		WithDiskReapInterval(diskReapInterval(memory.reapInterval)),
+	)
+
+	if err != nil {
+		memory.Close()
+		return nil, err
+	}
+
+	return NewLayered(memory, disk), nil
+}
+
+func diskReapInterval(memoryInterval time.Duration) time.Duration {
+	if memoryInterval <= 0 {
+		return 0
+	}
+
+	return max(memoryInterval, defaultDiskReapInterval)
+}
+
+func (l *Layered) Get(key string) ([]byte, bool) {
+	if val, ok := l.upper.Get(key); ok {
+		return val, true
+	}
+
+	val, freshness, ok := l.lookup(key)
+
+	if !ok || freshness != Fresh {
+		return nil, false
+	}
+
+	return val, true
+}
+
+func (l *Layered) GetStale(key string) ([]byte, Freshness, bool) {
+	val, freshness, ok := GetStale(l.upper, key)
+
+	if ok && freshness == Fresh {
+		return val, freshness, ok
+	}
+
+	lowerVal, lowerFreshness, lowerOk := l.lookup(key)
+
+	if !lowerOk {
+		return val, freshness, ok
+	}
+
+	if ok && freshness <= lowerFreshness {
+		return val, freshness, ok
+	}
+
+	return lowerVal, lowerFreshness, true
+}
+
+func (l *Layered) lookup(key string) ([]byte, Freshness, bool) {
+	es, ok := l.lower.(EntryStore)
+
+	if !ok {
+		val, freshness, ok := GetStale(l.lower, key)
+
+		if ok && freshness == Fresh {
+			l.upper.Add(key, val)
+		}
+
+		return val, freshness, ok
+	}
+
+	entry, freshness, ok := es.GetEntry(key)
+
+	if ok && freshness == Fresh {
+		AddWithValidators(l.upper, key, entry.Val, entry.TTL, entry.Validators)
+	}
+
+	return entry.Val, freshness, ok
+}
+
+func (l *Layered) Add(key string, val []byte) {
+	l.upper.Add(key, val)
+	l.lower.Add(key, val)
+}
+
+func (l *Layered) AddWithTTL(key string, val []byte, ttl time.Duration) {
+	AddWithTTL(l.upper, key, val, ttl)
+	AddWithTTL(l.lower, key, val, ttl)
+}
+
+func (l *Layered) AddWithValidators(key string, val []byte, ttl time.Duration, validators Validators) {
+	AddWithValidators(l.upper, key, val, ttl, validators)
+	AddWithValidators(l.lower, key, val, ttl, validators)
+}
+
+func (l *Layered) Restore(entry SnapshotEntry) {
+	Restore(l.upper, entry)
+	Restore(l.lower, entry)
+}
+
+func (l *Layered) GetValidators(key string) (Validators, bool) {
+	if validators, ok := GetValidators(l.upper, key); ok {
+		return validators, true
+	}
+
+	return GetValidators(l.lower, key)
+}
+
+func (l *Layered) GetDecoded(key string, raw []byte) (any, bool) {
+	if ds, ok := l.upper.(DecodedStore); ok {
+		return ds.GetDecoded(key, raw)
+	}
+
+	return nil, false
+}
+
+func (l *Layered) SetDecoded(key string, raw []byte, value any) {
+	if ds, ok := l.upper.(DecodedStore); ok {
+		ds.SetDecoded(key, raw, value)
+	}
+}
+
+func (l *Layered) Delete(key string) {
+	l.upper.Delete(key)
+	l.lower.Delete(key)
+}
+
+func (l *Layered) Stats() Stats {
+	upper := l.upper.Stats()
+	lower := l.lower.Stats()
+
+	return Stats{
+		Entries:     lower.Entries,
+		Bytes:       lower.Bytes,
+		Hits:        upper.Hits + lower.Hits,
+		StaleHits:   upper.StaleHits + lower.StaleHits,
+		Misses:      lower.Misses,
+		Evictions:   upper.Evictions + lower.Evictions,
+		Expirations: upper.Expirations + lower.Expirations,
+		BytesSaved:  upper.BytesSaved + lower.BytesSaved,
+		WriteErrors: upper.WriteErrors + lower.WriteErrors,
+	}
+}
+
+func (l *Layered) Keys() []string {
+	seen := map[string]bool{}
+	keys := []string{}
+
+	for _, key := range append(Keys(l.upper), Keys(l.lower)...) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (l *Layered) Snapshot() []SnapshotEntry {
+	byKey := map[string]SnapshotEntry{}
+
+	for _, store := range []Store{l.lower, l.upper} {
+		snap, ok := store.(Snapshotter)
+
+		if !ok {
+			continue
+		}
+
+		for _, entry := range snap.Snapshot() {
+			byKey[entry.Key] = entry
+		}
+	}
+
+	entries := make([]SnapshotEntry, 0, len(byKey))
+
+	for _, entry := range byKey {
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func (l *Layered) Close() error {
+	return errors.Join(l.upper.Close(), l.lower.Close())
+}
@@ -1,28 +1,164 @@
 package cache
 
 import (
+	"container/list"
+	"sort"
 	"sync"
 	"time"
 )
 
+const NoExpiry time.Duration = 0
+
+const defaultReapInterval = 5 * time.Second
+
+const defaultMaxDecoded = 32
+
+type Freshness int
+
+const (
+	Fresh Freshness = iota
+	StaleWhileRevalidate
+	StaleIfError
+	Expired
+)
+
 type Cache struct {
-	Data map[string]CacheEntry
-	mu   sync.Mutex
+	Data            map[string]CacheEntry
+	mu              sync.Mutex
+	ttl             time.Duration
+	reapInterval    time.Duration
+	staleRevalidate time.Duration
+	staleIfError    time.Duration
+	lru             *list.List
+	decodedLRU      *list.List
+	maxDecoded      int
+	bytes           int
+	maxBytes        int
+	maxEntries      int
+	compressAbove   int
+	done            chan struct{}
+	closeOnce       sync.Once
+	reaper          sync.WaitGroup
+	clock           Clock
+	stats           Stats
 }
 
 type CacheEntry struct {
-	createdAt time.Time
-	Val       []byte
+	createdAt  time.Time
+	expiresAt  time.Time
+	Val        []byte
+	Validators Validators
+	decoded    any
+	compressed bool
+	rawLen     int
+	sum        uint32
+	size       int
+	element    *list.Element
+	decodedAt  *list.Element
+}
+
+type Option func(*Cache)
+
+func WithMaxBytes(n int) Option {
+	return func(c *Cache) {
+		c.maxBytes = n
+	}
+}
+
+func WithMaxEntries(n int) Option {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
+}
+
+func WithMaxDecoded(n int) Option {
+	return func(c *Cache) {
+		c.maxDecoded = n
+	}
+}
+
+func WithReapInterval(interval time.Duration) Option {
+	return func(c *Cache) {
+		c.reapInterval = interval
+	}
+}
+
+func WithCompression(threshold int) Option {
+	return func(c *Cache) {
+		c.compressAbove = threshold
+	}
+}
+
+func WithClock(clock Clock) Option {
+	return func(c *Cache) {
+		c.clock = clock
+	}
+}
+
+func WithStaleWhileRevalidate(window time.Duration) Option {
+	return func(c *Cache) {
+		c.staleRevalidate = window
+	}
+}
+
+func WithStaleIfError(window time.Duration) Option {
+	return func(c *Cache) {
+		c.staleIfError = window
+	}
 }
 
 func (c *Cache) Add(key string, val []byte) {
+	c.AddWithTTL(key, val, c.ttl)
+}
+
+func (c *Cache) AddWithTTL(key string, val []byte, ttl time.Duration) {
+	c.AddWithValidators(key, val, ttl, Validators{})
+}
+
+func (c *Cache) AddWithValidators(key string, val []byte, ttl time.Duration, validators Validators) {
+	now := c.clock.Now()
+
+	c.put(key, val, validators, now, expiresAt(now, ttl))
+}
+
+func (c *Cache) Restore(entry SnapshotEntry) {
+	c.put(entry.Key, entry.Val, entry.Validators, entry.CreatedAt, entry.expiry())
+}
+
+func (c *Cache) put(key string, val []byte, validators Validators, createdAt time.Time, expiresAt time.Time) {
+	stored, compressed := maybeCompress(val, c.compressAbove)
+
+	entry := CacheEntry{
+		createdAt:  createdAt,
+		expiresAt:  expiresAt,
+		Val:        stored,
+		Validators: validators,
+		compressed: compressed,
+		rawLen:     len(val),
+		sum:        checksum(val),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dead(entry, c.clock.Now()) {
+		return
+	}
+
+	c.set(key, entry)
+}
+
+func (c *Cache) GetValidators(key string) (Validators, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.Data[key] = CacheEntry{
-		createdAt: time.Now(),
-		Val:       val,
+	data, ok := c.Data[key]
+
+	if !ok || c.dead(data, c.clock.Now()) || data.Validators.Empty() {
+		return Validators{}, false
 	}
+
+	return data.Validators, true
 }
 
 func (c *Cache) Get(key string) ([]byte, bool) {
@@ -30,43 +166,380 @@ func (c *Cache) Get(key string) ([]byte, bool) {
 	defer c.mu.Unlock()
 
 	data, ok := c.Data[key]
+	now := c.clock.Now()
+
+	if ok && c.expired(data, now) {
+		if c.dead(data, now) {
+			c.remove(key)
+			c.stats.Expirations++
+		}
+
+		c.stats.Misses++
+
+		return nil, false
+	}
 
 	if ok {
-		return data.Val, true
+		if val, ok := c.value(data); ok {
+			c.lru.MoveToFront(data.element)
+			c.stats.Hits++
+
+			return val, true
+		}
 	}
 
-	return data.Val, false
+	c.stats.Misses++
 
+	return nil, false
 }
 
-func (c *Cache) reaploop(interval time.Duration) {
-	ticker := time.NewTicker(5 * time.Second)
+func (c *Cache) GetStale(key string) ([]byte, Freshness, bool) {
+	entry, freshness, ok := c.GetEntry(key)
 
-	for {
-		_ = <-ticker.C
+	return entry.Val, freshness, ok
+}
+
+func (c *Cache) GetEntry(key string) (Entry, Freshness, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, ok := c.Data[key]
+
+	if !ok {
+		c.stats.Misses++
+		return Entry{}, Fresh, false
+	}
+
+	now := c.clock.Now()
+
+	if c.dead(data, now) {
+		c.remove(key)
+		c.stats.Expirations++
+		c.stats.Misses++
+
+		return Entry{}, Fresh, false
+	}
+
+	val, ok := c.value(data)
+
+	if !ok {
+		c.stats.Misses++
+		return Entry{}, Fresh, false
+	}
+
+	c.lru.MoveToFront(data.element)
+
+	entry := Entry{
+		Val:        val,
+		TTL:        remaining(data.expiresAt, now),
+		Validators: data.Validators,
+	}
+
+	if !c.expired(data, now) {
+		c.stats.Hits++
+		return entry, Fresh, true
+	}
+
+	if c.pastStale(data, now) {
+		c.stats.Misses++
+		return entry, Expired, true
+	}
+
+	c.stats.StaleHits++
+
+	if now.Sub(data.expiresAt) < c.staleRevalidate {
+		return entry, StaleWhileRevalidate, true
+	}
+
+	return entry, StaleIfError, true
+}
+
+func (c *Cache) GetDecoded(key string, raw []byte) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, ok := c.Data[key]
+
+	if !ok || data.decoded == nil || !data.matches(raw) {
+		return nil, false
+	}
+
+	c.decodedLRU.MoveToFront(data.decodedAt)
+
+	return data.decoded, true
+}
+
+func (c *Cache) SetDecoded(key string, raw []byte, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, ok := c.Data[key]
+
+	if !ok || c.maxDecoded <= 0 || !data.matches(raw) {
+		return
+	}
+
+	if data.decodedAt == nil {
+		data.decodedAt = c.decodedLRU.PushFront(key)
+	} else {
+		c.decodedLRU.MoveToFront(data.decodedAt)
+	}
+
+	data.decoded = value
+	c.Data[key] = data
+
+	for c.decodedLRU.Len() > c.maxDecoded {
+		c.dropDecoded(c.decodedLRU.Back().Value.(string))
+	}
+}
+
+func (c *Cache) dropDecoded(key string) {
+	data, ok := c.Data[key]
+
+	if !ok || data.decodedAt == nil {
+		return
+	}
+
+	c.decodedLRU.Remove(data.decodedAt)
+
+	data.decoded = nil
+	data.decodedAt = nil
+	c.Data[key] = data
+}
+
+func (c *Cache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(key)
+}
+
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = len(c.Data)
+	stats.Bytes = c.bytes
+
+	return stats
+}
+
+func (c *Cache) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.Data))
+
+	for key := range c.Data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (c *Cache) Snapshot() []SnapshotEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	entries := []SnapshotEntry{}
+
+	for key, entry := range c.Data {
+		if c.dead(entry, now) {
+			continue
+		}
+
+		val, ok := c.value(entry)
+
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, newSnapshotEntry(key, entry.createdAt, entry.expiresAt, val, entry.Validators))
+	}
+
+	return entries
+}
+
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.Data)
+}
+
+func (c *Cache) Bytes() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.bytes
+}
+
+func (c *Cache) set(key string, entry CacheEntry) {
+	if _, ok := c.Data[key]; ok {
+		c.remove(key)
+	}
+
+	entry.size = len(key) + len(entry.Val)
+	entry.element = c.lru.PushFront(key)
+
+	c.Data[key] = entry
+	c.bytes += entry.size
+
+	if entry.compressed {
+		c.stats.BytesSaved += entry.rawLen - len(entry.Val)
+	}
+
+	c.evict()
+}
+
+func (c *Cache) remove(key string) {
+	entry, ok := c.Data[key]
+
+	if !ok {
+		return
+	}
+
+	c.lru.Remove(entry.element)
+	c.bytes -= entry.size
+
+	if entry.decodedAt != nil {
+		c.decodedLRU.Remove(entry.decodedAt)
+	}
+
+	if entry.compressed {
+		c.stats.BytesSaved -= entry.rawLen - len(entry.Val)
+	}
+
+	delete(c.Data, key)
+}
+
+func (c *Cache) evict() {
+	for c.overBudget() {
+		oldest := c.lru.Back()
+
+		if oldest == nil {
+			return
+		}
+
+		c.remove(oldest.Value.(string))
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache) overBudget() bool {
+	if c.maxEntries > 0 && len(c.Data) > c.maxEntries {
+		return true
+	}
+
+	if c.maxBytes > 0 && c.bytes > c.maxBytes {
+		return true
+	}
+
+	return false
+}
+
+func (c *Cache) value(entry CacheEntry) ([]byte, bool) {
+	if !entry.compressed {
+		return entry.Val, true
+	}
+
+	val, err := decompress(entry.Val)
+
+	if err != nil {
+		return nil, false
+	}
 
-		c.mu.Lock()
+	return val, true
+}
+
+func (e CacheEntry) matches(raw []byte) bool {
+	if !e.compressed && sameBytes(e.Val, raw) {
+		return true
+	}
+
+	return len(raw) == e.rawLen && checksum(raw) == e.sum
+}
+
+func (c *Cache) expired(entry CacheEntry, now time.Time) bool {
+	return isExpired(entry.expiresAt, now)
+}
+
+func (c *Cache) dead(entry CacheEntry, now time.Time) bool {
+	return c.pastStale(entry, now) && entry.Validators.Empty()
+}
+
+func (c *Cache) pastStale(entry CacheEntry, now time.Time) bool {
+	if !c.expired(entry, now) {
+		return false
+	}
+
+	return now.Sub(entry.expiresAt) >= max(c.staleRevalidate, c.staleIfError)
+}
+
+func (c *Cache) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
 
-		for key, value := range c.Data {
-			now := time.Now()
+	c.reaper.Wait()
 
-			if now.Unix()-value.createdAt.Unix() > int64(interval) {
-				delete(c.Data, key)
-			}
+	return nil
+}
+
+func (c *Cache) reaploop(ticker Ticker) {
+	defer c.reaper.Done()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.Chan():
 		}
 
-		c.mu.Unlock()
+		c.reap()
+	}
+}
+
+func (c *Cache) reap() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
+	now := c.clock.Now()
+
+	for key, value := range c.Data {
+		if c.dead(value, now) {
+			c.remove(key)
+			c.stats.Expirations++
+		}
 	}
 }
 
-func NewCache(duration time.Duration) *Cache {
+func NewCache(ttl time.Duration, opts ...Option) *Cache {
 	c := Cache{
-		Data: map[string]CacheEntry{},
-		mu:   sync.Mutex{},
+		Data:         map[string]CacheEntry{},
+		mu:           sync.Mutex{},
+		ttl:          ttl,
+		reapInterval: defaultReapInterval,
+		lru:          list.New(),
+		decodedLRU:   list.New(),
+		maxDecoded:   defaultMaxDecoded,
+		done:         make(chan struct{}),
+		clock:        realClock{},
 	}
 
-	go c.reaploop(duration)
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.reapInterval > 0 {
+		c.reaper.Add(1)
+		go c.reaploop(c.clock.NewTicker(c.reapInterval))
+	}
 
 	return &c
 }
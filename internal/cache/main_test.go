@@ -2,6 +2,10 @@ package cache
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
 	"testing"
 	"time"
 )
@@ -23,8 +27,9 @@ func TestAddCacheKey(t *testing.T) {
 	}
 
 	for index, testCase := range cases {
-		t.Run(fmt.Sprintf("Running test case %v: ", index), func(*testing.T) {
+		t.Run(fmt.Sprintf("Running test case %v: ", index), func(t *testing.T) {
 			cache := NewCache(interval)
+			defer cache.Close()
 
 			cache.Add(testCase.key, testCase.val)
 			data, ok := cache.Get(testCase.key)
@@ -43,27 +48,849 @@ func TestAddCacheKey(t *testing.T) {
 }
 
 func TestCacheReap(t *testing.T) {
-	const reaper = 100 * time.Millisecond
-	const wait = 150 * time.Millisecond
+	cases := []struct {
+		name    string
+		ttl     time.Duration
+		advance time.Duration
+		reaped  bool
+	}{
+		{
+			name:    "expired entry is reaped",
+			ttl:     time.Minute,
+			advance: 2 * time.Minute,
+			reaped:  true,
+		},
+		{
+			name:    "fresh entry is kept",
+			ttl:     time.Hour,
+			advance: 2 * time.Minute,
+			reaped:  false,
+		},
+		{
+			name:    "entry without expiry is kept",
+			ttl:     NoExpiry,
+			advance: 24 * time.Hour,
+			reaped:  false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			clock := newFakeClock()
+
+			cache := NewCache(testCase.ttl, WithClock(clock), WithReapInterval(time.Second))
+			defer cache.Close()
+
+			cache.Add("www.google.com", []byte("some data"))
+			cache.Add("www.example.com", []byte("some data"))
+
+			clock.Advance(testCase.advance)
+
+			if testCase.reaped {
+				eventually(t, func() bool { return cache.Len() == 0 })
+				return
+			}
+
+			cache.reap()
+
+			if _, ok := cache.Get("www.google.com"); !ok {
+				t.Errorf("Expected key to be kept")
+			}
+		})
+	}
+}
 
-	cache := NewCache(reaper)
+func TestPersistentCache(t *testing.T) {
+	const interval = time.Minute
+	dir := t.TempDir()
 
-	cache.Add("www.google.com", []byte("some data"))
+	cache, err := NewPersistentCache(interval, dir)
 
-	_, ok := cache.Get("www.google.com")
+	if err != nil {
+		t.Fatalf("Unable to create persistent cache: %v", err)
+	}
+
+	defer cache.Close()
+
+	cache.Add("www.example.com", []byte("somedata"))
+
+	reloaded, err := NewPersistentCache(interval, dir)
+
+	if err != nil {
+		t.Fatalf("Unable to reload persistent cache: %v", err)
+	}
+
+	defer reloaded.Close()
+
+	data, ok := reloaded.Get("www.example.com")
 
 	if !ok {
-		t.Errorf("Key not found in cache")
+		t.Errorf("Key not found in reloaded cache")
 		return
 	}
 
-	time.Sleep(wait)
+	if string(data) != "somedata" {
+		t.Errorf("Key data did not match. Got %v wanted %v", string(data), "somedata")
+	}
+}
+
+func TestPersistentCacheKeepsStaleEntries(t *testing.T) {
+	const key = "www.example.com"
+	const plainKey = "www.example.com/plain"
+
+	dir := t.TempDir()
+	clock := newFakeClock()
+
+	open := func() *Layered {
+		cache, err := NewPersistentCache(time.Minute, dir, WithClock(clock), WithStaleWhileRevalidate(time.Minute), WithStaleIfError(time.Hour))
+
+		if err != nil {
+			t.Fatalf("Unable to create persistent cache: %v", err)
+		}
+
+		return cache
+	}
+
+	cache := open()
+	cache.AddWithValidators(key, []byte("somedata"), time.Minute, Validators{ETag: `"v1"`})
+	cache.Add(plainKey, []byte("plaindata"))
+	cache.Close()
+
+	cases := []struct {
+		name       string
+		advance    time.Duration
+		freshness  Freshness
+		found      bool
+		validators bool
+		plain      bool
+	}{
+		{name: "fresh", advance: 0, freshness: Fresh, found: true, validators: true, plain: true},
+		{name: "stale while revalidate", advance: 90 * time.Second, freshness: StaleWhileRevalidate, found: true, validators: true, plain: true},
+		{name: "stale if error", advance: 10 * time.Minute, freshness: StaleIfError, found: true, validators: true, plain: true},
+		{name: "past the stale windows", advance: 2 * time.Hour, freshness: Expired, found: true, validators: true, plain: false},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			clock.Advance(testCase.advance)
+
+			reloaded := open()
+			defer reloaded.Close()
+
+			val, freshness, ok := reloaded.GetStale(key)
+
+			if ok != testCase.found || freshness != testCase.freshness || (ok && string(val) != "somedata") {
+				t.Errorf("Unexpected stale read. Got %v %v %v wanted %v %v", string(val), freshness, ok, testCase.freshness, testCase.found)
+			}
+
+			if _, ok := reloaded.GetValidators(key); ok != testCase.validators {
+				t.Errorf("Validators found did not match. Got %v wanted %v", ok, testCase.validators)
+			}
+
+			if _, _, ok := reloaded.GetStale(plainKey); ok != testCase.plain {
+				t.Errorf("Entry without validators found did not match. Got %v wanted %v", ok, testCase.plain)
+			}
+		})
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	const interval = time.Minute
+	cases := []struct {
+		name    string
+		opts    []Option
+		evicted string
+		kept    []string
+	}{
+		{
+			name:    "max entries",
+			opts:    []Option{WithMaxEntries(2)},
+			evicted: "b",
+			kept:    []string{"a", "c"},
+		},
+		{
+			name:    "max bytes",
+			opts:    []Option{WithMaxBytes(20)},
+			evicted: "b",
+			kept:    []string{"a", "c"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cache := NewCache(interval, testCase.opts...)
+			defer cache.Close()
+
+			cache.Add("a", []byte("123456789"))
+			cache.Add("b", []byte("123456789"))
+			cache.Get("a")
+			cache.Add("c", []byte("123456789"))
+
+			if _, ok := cache.Get(testCase.evicted); ok {
+				t.Errorf("Expected %v to be evicted", testCase.evicted)
+			}
+
+			for _, key := range testCase.kept {
+				if _, ok := cache.Get(key); !ok {
+					t.Errorf("Expected %v to be kept", key)
+				}
+			}
+
+			if cache.Len() != 2 || cache.Bytes() != 20 {
+				t.Errorf("Unexpected usage. Got %v entries and %v bytes", cache.Len(), cache.Bytes())
+			}
+		})
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	cases := []struct {
+		name    string
+		ttl     time.Duration
+		advance time.Duration
+		expired bool
+	}{
+		{
+			name:    "expired entry",
+			ttl:     10 * time.Second,
+			advance: 20 * time.Second,
+			expired: true,
+		},
+		{
+			name:    "entry on its expiry",
+			ttl:     10 * time.Second,
+			advance: 10 * time.Second,
+			expired: true,
+		},
+		{
+			name:    "fresh entry",
+			ttl:     time.Minute,
+			advance: 20 * time.Second,
+			expired: false,
+		},
+		{
+			name:    "no expiry",
+			ttl:     NoExpiry,
+			advance: 24 * time.Hour,
+			expired: false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			clock := newFakeClock()
+
+			cache := NewCache(time.Minute, WithClock(clock), WithReapInterval(0))
+			defer cache.Close()
+
+			cache.AddWithTTL("www.example.com", []byte("somedata"), testCase.ttl)
+
+			clock.Advance(testCase.advance)
+
+			_, ok := cache.Get("www.example.com")
+
+			if ok == testCase.expired {
+				t.Errorf("Expected expired to be %v", testCase.expired)
+			}
+		})
+	}
+}
+
+func TestCacheRefresh(t *testing.T) {
+	cases := []struct {
+		name    string
+		refresh func(cache *Cache)
+	}{
+		{
+			name: "add",
+			refresh: func(cache *Cache) {
+				cache.Add("www.example.com", []byte("newdata"))
+			},
+		},
+		{
+			name: "add with validators",
+			refresh: func(cache *Cache) {
+				cache.AddWithValidators("www.example.com", []byte("newdata"), time.Minute, Validators{ETag: `"v2"`})
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			clock := newFakeClock()
+
+			cache := NewCache(time.Minute, WithClock(clock), WithReapInterval(0))
+			defer cache.Close()
+
+			cache.Add("www.example.com", []byte("somedata"))
+
+			clock.Advance(50 * time.Second)
+
+			testCase.refresh(cache)
+
+			clock.Advance(50 * time.Second)
+
+			data, ok := cache.Get("www.example.com")
+
+			if !ok {
+				t.Errorf("Expected refreshed entry to still be fresh")
+				return
+			}
+
+			if string(data) != "newdata" {
+				t.Errorf("Key data did not match. Got %v wanted %v", string(data), "newdata")
+			}
+
+			if cache.Len() != 1 || cache.Bytes() != len("www.example.com")+len("newdata") {
+				t.Errorf("Unexpected usage. Got %v entries and %v bytes", cache.Len(), cache.Bytes())
+			}
+		})
+	}
+}
+
+func TestCacheReapInterval(t *testing.T) {
+	clock := newFakeClock()
+
+	cache := NewCache(time.Minute, WithClock(clock), WithReapInterval(10*time.Second))
+	defer cache.Close()
+
+	cache.Add("www.example.com", []byte("somedata"))
+
+	clock.Advance(5 * time.Minute)
+
+	eventually(t, func() bool { return cache.Len() == 0 })
+}
+
+func TestCacheGetStale(t *testing.T) {
+	const ttl = 10 * time.Second
+	cases := []struct {
+		name      string
+		opts      []Option
+		advance   time.Duration
+		found     bool
+		freshness Freshness
+	}{
+		{
+			name:      "fresh entry",
+			advance:   0,
+			found:     true,
+			freshness: Fresh,
+		},
+		{
+			name:    "expired without stale windows",
+			advance: 20 * time.Second,
+			found:   false,
+		},
+		{
+			name:      "inside revalidate window",
+			opts:      []Option{WithStaleWhileRevalidate(time.Minute)},
+			advance:   20 * time.Second,
+			found:     true,
+			freshness: StaleWhileRevalidate,
+		},
+		{
+			name:      "inside stale if error window",
+			opts:      []Option{WithStaleWhileRevalidate(time.Second), WithStaleIfError(time.Minute)},
+			advance:   20 * time.Second,
+			found:     true,
+			freshness: StaleIfError,
+		},
+		{
+			name:    "outside every window",
+			opts:    []Option{WithStaleWhileRevalidate(time.Second), WithStaleIfError(time.Second)},
+			advance: 20 * time.Second,
+			found:   false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			clock := newFakeClock()
+
+			opts := append([]Option{WithClock(clock), WithReapInterval(0)}, testCase.opts...)
+
+			cache := NewCache(ttl, opts...)
+			defer cache.Close()
+
+			cache.Add("www.example.com", []byte("somedata"))
+
+			clock.Advance(testCase.advance)
+
+			if _, ok := cache.Get("www.example.com"); ok && testCase.advance > 0 {
+				t.Errorf("Get returned an expired entry")
+			}
+
+			_, freshness, ok := cache.GetStale("www.example.com")
+
+			if ok != testCase.found {
+				t.Errorf("Expected found to be %v", testCase.found)
+				return
+			}
+
+			if ok && freshness != testCase.freshness {
+				t.Errorf("Freshness did not match. Got %v wanted %v", freshness, testCase.freshness)
+			}
+		})
+	}
+}
+
+func TestCacheConcurrency(t *testing.T) {
+	const workers = 8
+	const operations = 200
+
+	cases := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "unbounded",
+		},
+		{
+			name: "evicting",
+			opts: []Option{WithMaxEntries(10), WithMaxBytes(200)},
+		},
+		{
+			name: "reaping",
+			opts: []Option{WithReapInterval(time.Millisecond)},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cache := NewCache(time.Millisecond, testCase.opts...)
+			defer cache.Close()
+
+			var wg sync.WaitGroup
+
+			for worker := 0; worker < workers; worker++ {
+				wg.Add(1)
+
+				go func(worker int) {
+					defer wg.Done()
+
+					for i := 0; i < operations; i++ {
+						key := fmt.Sprintf("key-%v", (worker+i)%20)
+
+						switch i % 4 {
+						case 0:
+							cache.Add(key, []byte("somedata"))
+						case 1:
+							cache.Get(key)
+						case 2:
+							cache.GetStale(key)
+						case 3:
+							cache.Delete(key)
+						}
+					}
+				}(worker)
+			}
+
+			wg.Wait()
+			cache.Close()
+
+			stats := cache.Stats()
+
+			if stats.Entries != cache.lru.Len() {
+				t.Errorf("LRU list out of sync. Got %v entries and %v list elements", stats.Entries, cache.lru.Len())
+			}
+		})
+	}
+}
+
+func TestStores(t *testing.T) {
+	disk, err := NewDiskStore(t.TempDir(), time.Minute)
+
+	if err != nil {
+		t.Fatalf("Unable to create disk store: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		store   Store
+		persist bool
+	}{
+		{
+			name:    "memory",
+			store:   NewCache(time.Minute),
+			persist: true,
+		},
+		{
+			name:    "disk",
+			store:   disk,
+			persist: true,
+		},
+		{
+			name:    "layered",
+			store:   NewLayered(NewCache(time.Minute), NewCache(time.Minute)),
+			persist: true,
+		},
+		{
+			name:    "noop",
+			store:   Noop{},
+			persist: false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			defer testCase.store.Close()
+
+			testCase.store.Add("www.example.com", []byte("somedata"))
+
+			data, ok := testCase.store.Get("www.example.com")
+
+			if ok != testCase.persist {
+				t.Errorf("Expected found to be %v", testCase.persist)
+				return
+			}
+
+			if ok && string(data) != "somedata" {
+				t.Errorf("Key data did not match. Got %v wanted %v", string(data), "somedata")
+			}
+
+			if ok && testCase.store.Stats().Entries != 1 {
+				t.Errorf("Expected one entry, got %v", testCase.store.Stats().Entries)
+			}
+
+			testCase.store.Delete("www.example.com")
+
+			if _, ok := testCase.store.Get("www.example.com"); ok {
+				t.Errorf("Expected key to be deleted")
+			}
+		})
+	}
+}
+
+func TestLayeredPromotesFromLower(t *testing.T) {
+	lower := NewCache(time.Minute)
+	defer lower.Close()
+	upper := NewCache(time.Minute)
+	defer upper.Close()
 
-	_, ok = cache.Get("www.gooogle.com")
+	lower.Add("www.example.com", []byte("somedata"))
 
-	if ok {
-		t.Errorf("Expected key to be removed")
+	if _, ok := NewLayered(upper, lower).Get("www.example.com"); !ok {
+		t.Errorf("Expected layered store to read through to the lower store")
 		return
 	}
 
+	if _, ok := upper.Get("www.example.com"); !ok {
+		t.Errorf("Expected entry to be promoted into the upper store")
+	}
+}
+
+func TestLayeredPromotionKeepsTTLAndValidators(t *testing.T) {
+	dir := t.TempDir()
+	clock := newFakeClock()
+	validators := Validators{ETag: `"v1"`}
+
+	cases := []struct {
+		name     string
+		key      string
+		ttl      time.Duration
+		expected time.Duration
+	}{
+		{name: "no expiry", key: "pokemon/pikachu", ttl: NoExpiry, expected: NoExpiry},
+		{name: "remaining ttl", key: "location-area/canalave-city-area", ttl: time.Hour, expected: 45 * time.Minute},
+	}
+
+	cache, err := NewPersistentCache(time.Minute, dir, WithClock(clock))
+
+	if err != nil {
+		t.Fatalf("Unable to create persistent cache: %v", err)
+	}
+
+	for _, testCase := range cases {
+		cache.AddWithValidators(testCase.key, []byte("somedata"), testCase.ttl, validators)
+	}
+
+	cache.Close()
+
+	clock.Advance(15 * time.Minute)
+
+	reloaded, err := NewPersistentCache(time.Minute, dir, WithClock(clock))
+
+	if err != nil {
+		t.Fatalf("Unable to reload persistent cache: %v", err)
+	}
+
+	defer reloaded.Close()
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, ok := reloaded.Get(testCase.key); !ok {
+				t.Fatalf("Key not found in reloaded cache")
+			}
+
+			for _, entry := range reloaded.upper.(Snapshotter).Snapshot() {
+				if entry.Key != testCase.key {
+					continue
+				}
+
+				if entry.TTL != testCase.expected || entry.Validators != validators {
+					t.Errorf("Promoted entry did not match. Got ttl=%v validators=%v wanted ttl=%v validators=%v", entry.TTL, entry.Validators, testCase.expected, validators)
+				}
+
+				return
+			}
+
+			t.Errorf("Expected entry to be promoted into the memory layer")
+		})
+	}
+}
+
+func TestStoreValidators(t *testing.T) {
+	disk, err := NewDiskStore(t.TempDir(), time.Minute)
+
+	if err != nil {
+		t.Fatalf("Unable to create disk store: %v", err)
+	}
+
+	validators := Validators{
+		ETag:         `W/"abc"`,
+		LastModified: "Mon, 02 Jan 2006 15:04:05 GMT",
+		MaxAge:       time.Hour,
+	}
+
+	cases := []struct {
+		name  string
+		store Store
+	}{
+		{
+			name:  "memory",
+			store: NewCache(time.Minute),
+		},
+		{
+			name:  "disk",
+			store: disk,
+		},
+		{
+			name:  "layered",
+			store: NewLayered(NewCache(time.Minute), NewCache(time.Minute)),
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			defer testCase.store.Close()
+
+			AddWithValidators(testCase.store, "www.example.com", []byte("somedata"), time.Minute, validators)
+
+			got, ok := GetValidators(testCase.store, "www.example.com")
+
+			if !ok {
+				t.Errorf("Validators not found")
+				return
+			}
+
+			if got != validators {
+				t.Errorf("Validators did not match. Got %v wanted %v", got, validators)
+			}
+		})
+	}
+}
+
+func TestCacheCloseStopsReaper(t *testing.T) {
+	const caches = 20
+
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < caches; i++ {
+		cache := NewCache(time.Minute, WithReapInterval(time.Millisecond))
+
+		if err := cache.Close(); err != nil {
+			t.Fatalf("Unexpected error closing cache: %v", err)
+		}
+
+		if err := cache.Close(); err != nil {
+			t.Fatalf("Unexpected error closing cache twice: %v", err)
+		}
+	}
+
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("Leaked goroutines. Got %v wanted at most %v", after, before)
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	clock := newFakeClock()
+
+	cache := NewCache(time.Minute, WithClock(clock), WithReapInterval(0), WithMaxEntries(2))
+	defer cache.Close()
+
+	cache.Add("a", []byte("123"))
+	cache.Add("b", []byte("123"))
+	cache.Get("a")
+	cache.Get("missing")
+	cache.Add("c", []byte("123"))
+
+	clock.Advance(2 * time.Minute)
+
+	cache.Get("a")
+
+	expected := Stats{
+		Entries:     1,
+		Bytes:       4,
+		Hits:        1,
+		Misses:      2,
+		Evictions:   1,
+		Expirations: 1,
+	}
+
+	if stats := cache.Stats(); stats != expected {
+		t.Errorf("Stats did not match. Got %+v wanted %+v", stats, expected)
+	}
+}
+
+func TestLayeredStatsCoverBothLayers(t *testing.T) {
+	upper := NewCache(time.Minute, WithReapInterval(0))
+	lower := NewCache(time.Minute, WithReapInterval(0))
+
+	layered := NewLayered(upper, lower)
+	defer layered.Close()
+
+	layered.Add("a", []byte("123"))
+	lower.Add("b", []byte("123"))
+
+	layered.Get("a")
+	layered.Get("b")
+	layered.Get("missing")
+
+	expected := Stats{
+		Entries: 2,
+		Bytes:   8,
+		Hits:    2,
+		Misses:  1,
+	}
+
+	if stats := layered.Stats(); stats != expected {
+		t.Errorf("Stats did not match. Got %+v wanted %+v", stats, expected)
+	}
+}
+
+func TestDiskStoreCountsWriteErrors(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+
+	disk, err := NewDiskStore(dir, time.Minute)
+
+	if err != nil {
+		t.Fatalf("Unable to create disk store: %v", err)
+	}
+
+	disk.Add("www.example.com", []byte("somedata"))
+
+	if got := disk.Stats().WriteErrors; got != 0 {
+		t.Errorf("Write errors did not match. Got %v wanted 0", got)
+	}
+
+	os.RemoveAll(dir)
+
+	disk.Add("www.example.com", []byte("somedata"))
+
+	if got := disk.Stats().WriteErrors; got != 1 {
+		t.Errorf("Write errors did not match. Got %v wanted 1", got)
+	}
+}
+
+func TestClear(t *testing.T) {
+	cases := []struct {
+		name    string
+		prefix  string
+		removed int
+		kept    []string
+	}{
+		{
+			name:    "everything",
+			prefix:  "",
+			removed: 3,
+			kept:    []string{},
+		},
+		{
+			name:    "prefix",
+			prefix:  "https://pokeapi.co/api/v2/pokemon/",
+			removed: 2,
+			kept:    []string{"https://pokeapi.co/api/v2/location-area/canalave-city-area"},
+		},
+		{
+			name:    "no match",
+			prefix:  "https://example.com/",
+			removed: 0,
+			kept: []string{
+				"https://pokeapi.co/api/v2/location-area/canalave-city-area",
+				"https://pokeapi.co/api/v2/pokemon/pikachu",
+				"https://pokeapi.co/api/v2/pokemon/raichu",
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := NewLayered(NewCache(time.Minute), NewCache(time.Minute))
+			defer store.Close()
+
+			store.Add("https://pokeapi.co/api/v2/pokemon/pikachu", []byte("somedata"))
+			store.Add("https://pokeapi.co/api/v2/pokemon/raichu", []byte("somedata"))
+			store.Add("https://pokeapi.co/api/v2/location-area/canalave-city-area", []byte("somedata"))
+
+			if removed := Clear(store, testCase.prefix); removed != testCase.removed {
+				t.Errorf("Removed count did not match. Got %v wanted %v", removed, testCase.removed)
+			}
+
+			if keys := Keys(store); fmt.Sprint(keys) != fmt.Sprint(testCase.kept) {
+				t.Errorf("Remaining keys did not match. Got %v wanted %v", keys, testCase.kept)
+			}
+		})
+	}
+}
+
+func TestDiskStoreReap(t *testing.T) {
+	cases := []struct {
+		name   string
+		reopen bool
+	}{
+		{name: "reaper"},
+		{name: "sweep on open", reopen: true},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dir := t.TempDir()
+			clock := newFakeClock()
+
+			disk, err := NewDiskStore(dir, time.Minute, WithDiskClock(clock), WithDiskReapInterval(time.Minute), WithDiskStaleIfError(time.Hour))
+
+			if err != nil {
+				t.Fatalf("Unable to create disk store: %v", err)
+			}
+
+			disk.Add("www.example.com/expired", []byte("somedata"))
+			disk.AddWithValidators("www.example.com/validated", []byte("somedata"), time.Minute, Validators{ETag: `"v1"`})
+			disk.AddWithTTL("www.example.com/stale", []byte("somedata"), 90*time.Minute)
+			disk.AddWithTTL("www.example.com/forever", []byte("somedata"), NoExpiry)
+
+			if testCase.reopen {
+				disk.Close()
+			}
+
+			clock.Advance(2 * time.Hour)
+
+			if testCase.reopen {
+				disk, err = NewDiskStore(dir, time.Minute, WithDiskClock(clock), WithDiskStaleIfError(time.Hour))
+
+				if err != nil {
+					t.Fatalf("Unable to reopen disk store: %v", err)
+				}
+			}
+
+			defer disk.Close()
+
+			eventually(t, func() bool { return disk.Stats().Expirations == 1 })
+
+			expected := "[www.example.com/forever www.example.com/stale www.example.com/validated]"
+
+			if keys := fmt.Sprint(disk.Keys()); keys != expected {
+				t.Errorf("Keys did not match. Got %v wanted %v", keys, expected)
+			}
+		})
+	}
 }
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+const snapshotVersion = 1
+
+type Snapshotter interface {
+	Snapshot() []SnapshotEntry
+}
+
+type Restorer interface {
+	Restore(entry SnapshotEntry)
+}
+
+type SnapshotEntry struct {
+	Key        string        `json:"key"`
+	CreatedAt  time.Time     `json:"created_at"`
+	TTL        time.Duration `json:"ttl"`
+	Val        []byte        `json:"val"`
+	Validators Validators    `json:"validators"`
+}
+
+type snapshot struct {
+	Version int             `json:"version"`
+	Entries []SnapshotEntry `json:"entries"`
+}
+
+func newSnapshotEntry(key string, createdAt time.Time, expiresAt time.Time, val []byte, validators Validators) SnapshotEntry {
+	ttl := NoExpiry
+
+	if !expiresAt.IsZero() {
+		ttl = expiresAt.Sub(createdAt)
+	}
+
+	return SnapshotEntry{
+		Key:        key,
+		CreatedAt:  createdAt,
+		TTL:        ttl,
+		Val:        val,
+		Validators: validators,
+	}
+}
+
+func (e SnapshotEntry) expiry() time.Time {
+	if e.CreatedAt.IsZero() {
+		return time.Time{}
+	}
+
+	return expiresAt(e.CreatedAt, e.TTL)
+}
+
+func Restore(s Store, entry SnapshotEntry) {
+	if r, ok := s.(Restorer); ok {
+		r.Restore(entry)
+		return
+	}
+
+	AddWithValidators(s, entry.Key, entry.Val, entry.TTL, entry.Validators)
+}
+
+func Export(s Store, w io.Writer) (int, error) {
+	var entries []SnapshotEntry
+
+	if snap, ok := s.(Snapshotter); ok {
+		entries = snap.Snapshot()
+	} else {
+		for _, key := range Keys(s) {
+			if val, ok := s.Get(key); ok {
+				entries = append(entries, SnapshotEntry{Key: key, Val: val})
+			}
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	gz := gzip.NewWriter(w)
+
+	err := json.NewEncoder(gz).Encode(snapshot{
+		Version: snapshotVersion,
+		Entries: entries,
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(entries), gz.Close()
+}
+
+func Import(s Store, r io.Reader) (int, error) {
+	gz, err := gzip.NewReader(r)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer gz.Close()
+
+	var snap snapshot
+
+	err = json.NewDecoder(gz).Decode(&snap)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if snap.Version != snapshotVersion {
+		return 0, errors.New(fmt.Sprintf("Unsupported snapshot version %v", snap.Version))
+	}
+
+	for _, entry := range snap.Entries {
+		Restore(s, entry)
+	}
+
+	return len(snap.Entries), nil
+}
@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	cases := []struct {
+		name   string
+		target func(t *testing.T) Store
+	}{
+		{
+			name: "memory",
+			target: func(t *testing.T) Store {
+				return NewCache(time.Minute, WithCompression(1))
+			},
+		},
+		{
+			name: "disk",
+			target: func(t *testing.T) Store {
+				disk, err := NewDiskStore(t.TempDir(), time.Minute)
+
+				if err != nil {
+					t.Fatalf("Unable to create disk store: %v", err)
+				}
+
+				return disk
+			},
+		},
+		{
+			name: "layered",
+			target: func(t *testing.T) Store {
+				return NewLayered(NewCache(time.Minute), NewCache(time.Minute))
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			clock := newFakeClock()
+
+			source := NewCache(time.Minute, WithClock(clock), WithReapInterval(0))
+			defer source.Close()
+
+			source.AddWithTTL("https://pokeapi.co/api/v2/pokemon/pikachu", []byte("pikachu"), NoExpiry)
+			source.AddWithValidators("https://pokeapi.co/api/v2/location-area/1", []byte("canalave"), time.Hour, Validators{ETag: `"v1"`})
+			source.AddWithTTL("https://pokeapi.co/api/v2/location-area/2", []byte("eterna"), time.Second)
+
+			clock.Advance(time.Minute)
+
+			var buf bytes.Buffer
+
+			exported, err := Export(source, &buf)
+
+			if err != nil || exported != 2 {
+				t.Fatalf("Unexpected export. Got %v entries and %v", exported, err)
+			}
+
+			target := testCase.target(t)
+			defer target.Close()
+
+			imported, err := Import(target, &buf)
+
+			if err != nil || imported != 2 {
+				t.Fatalf("Unexpected import. Got %v entries and %v", imported, err)
+			}
+
+			if keys := fmt.Sprint(Keys(target)); keys != "[https://pokeapi.co/api/v2/location-area/1 https://pokeapi.co/api/v2/pokemon/pikachu]" {
+				t.Errorf("Unexpected keys after import: %v", keys)
+			}
+
+			if data, ok := target.Get("https://pokeapi.co/api/v2/pokemon/pikachu"); !ok || string(data) != "pikachu" {
+				t.Errorf("Expected imported entry to be readable")
+			}
+
+			if validators, ok := GetValidators(target, "https://pokeapi.co/api/v2/location-area/1"); !ok || validators.ETag != `"v1"` {
+				t.Errorf("Expected validators to survive the round trip, got %v", validators)
+			}
+		})
+	}
+}
+
+func TestImportRejectsGarbage(t *testing.T) {
+	cache := NewCache(time.Minute)
+	defer cache.Close()
+
+	if _, err := Import(cache, bytes.NewReader([]byte("not a snapshot"))); err == nil {
+		t.Errorf("Expected an error importing garbage")
+	}
+}
+
+func TestImportKeepsRemainingTTL(t *testing.T) {
+	const freshKey = "https://pokeapi.co/api/v2/location-area/1"
+	const staleKey = "https://pokeapi.co/api/v2/location-area/2"
+
+	clock := newFakeClock()
+
+	cases := []struct {
+		name   string
+		target func(t *testing.T) Store
+	}{
+		{
+			name: "memory",
+			target: func(t *testing.T) Store {
+				return NewCache(time.Minute, WithClock(clock), WithReapInterval(0), WithStaleIfError(time.Hour))
+			},
+		},
+		{
+			name: "disk",
+			target: func(t *testing.T) Store {
+				disk, err := NewDiskStore(t.TempDir(), time.Minute, WithDiskClock(clock), WithDiskStaleIfError(time.Hour))
+
+				if err != nil {
+					t.Fatalf("Unable to create disk store: %v", err)
+				}
+
+				return disk
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			source := NewCache(time.Minute, WithClock(clock), WithReapInterval(0), WithStaleIfError(time.Hour))
+			defer source.Close()
+
+			source.AddWithTTL(freshKey, []byte("canalave"), time.Hour)
+			source.AddWithTTL(staleKey, []byte("eterna"), time.Minute)
+
+			clock.Advance(50 * time.Minute)
+
+			var buf bytes.Buffer
+
+			if exported, err := Export(source, &buf); err != nil || exported != 2 {
+				t.Fatalf("Unexpected export. Got %v entries and %v", exported, err)
+			}
+
+			target := testCase.target(t)
+			defer target.Close()
+
+			if _, err := Import(target, &buf); err != nil {
+				t.Fatalf("Unexpected import error: %v", err)
+			}
+
+			entry, freshness, ok := target.(EntryStore).GetEntry(freshKey)
+
+			if !ok || freshness != Fresh || entry.TTL != 10*time.Minute {
+				t.Errorf("Unexpected fresh entry. Got %v %v with %v left", ok, freshness, entry.TTL)
+			}
+
+			if val, freshness, ok := GetStale(target, staleKey); !ok || freshness != StaleIfError || string(val) != "eterna" {
+				t.Errorf("Unexpected stale entry. Got %v %v %v", string(val), freshness, ok)
+			}
+
+			if _, ok := target.Get(staleKey); ok {
+				t.Errorf("Expected the stale entry to stay expired after the import")
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"strings"
+	"time"
+)
+
+type Store interface {
+	Get(key string) ([]byte, bool)
+	Add(key string, val []byte)
+	Delete(key string)
+	Stats() Stats
+	Close() error
+}
+
+type TTLStore interface {
+	AddWithTTL(key string, val []byte, ttl time.Duration)
+}
+
+type StaleStore interface {
+	GetStale(key string) ([]byte, Freshness, bool)
+}
+
+type Entry struct {
+	Val        []byte
+	TTL        time.Duration
+	Validators Validators
+}
+
+type EntryStore interface {
+	GetEntry(key string) (Entry, Freshness, bool)
+}
+
+type ValidatorStore interface {
+	AddWithValidators(key string, val []byte, ttl time.Duration, validators Validators)
+	GetValidators(key string) (Validators, bool)
+}
+
+type Validators struct {
+	ETag         string        `json:"etag,omitempty"`
+	LastModified string        `json:"last_modified,omitempty"`
+	MaxAge       time.Duration `json:"max_age,omitempty"`
+}
+
+func (v Validators) Empty() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+type KeyLister interface {
+	Keys() []string
+}
+
+type Stats struct {
+	Entries     int
+	Bytes       int
+	Hits        int
+	StaleHits   int
+	Misses      int
+	Evictions   int
+	Expirations int
+	BytesSaved  int
+	WriteErrors int
+}
+
+func AddWithTTL(s Store, key string, val []byte, ttl time.Duration) {
+	if ts, ok := s.(TTLStore); ok {
+		ts.AddWithTTL(key, val, ttl)
+		return
+	}
+
+	s.Add(key, val)
+}
+
+func AddWithValidators(s Store, key string, val []byte, ttl time.Duration, validators Validators) {
+	if vs, ok := s.(ValidatorStore); ok {
+		vs.AddWithValidators(key, val, ttl, validators)
+		return
+	}
+
+	AddWithTTL(s, key, val, ttl)
+}
+
+func GetValidators(s Store, key string) (Validators, bool) {
+	if vs, ok := s.(ValidatorStore); ok {
+		return vs.GetValidators(key)
+	}
+
+	return Validators{}, false
+}
+
+func GetStale(s Store, key string) ([]byte, Freshness, bool) {
+	if ss, ok := s.(StaleStore); ok {
+		return ss.GetStale(key)
+	}
+
+	val, ok := s.Get(key)
+
+	return val, Fresh, ok
+}
+
+func Keys(s Store) []string {
+	if kl, ok := s.(KeyLister); ok {
+		return kl.Keys()
+	}
+
+	return nil
+}
+
+func Clear(s Store, prefix string) int {
+	removed := 0
+
+	for _, key := range Keys(s) {
+		if strings.HasPrefix(key, prefix) {
+			s.Delete(key)
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func expiresAt(now time.Time, ttl time.Duration) time.Time {
+	if ttl > NoExpiry {
+		return now.Add(ttl)
+	}
+
+	return time.Time{}
+}
+
+func remaining(expiresAt time.Time, now time.Time) time.Duration {
+	if expiresAt.IsZero() {
+		return NoExpiry
+	}
+
+	return expiresAt.Sub(now)
+}
+
+func isExpired(expiresAt time.Time, now time.Time) bool {
+	return !expiresAt.IsZero() && !now.Before(expiresAt)
+}
+
+type Noop struct{}
+
+func (Noop) Get(key string) ([]byte, bool) {
+	return nil, false
+}
+
+func (Noop) Add(key string, val []byte) {}
+
+func (Noop) Delete(key string) {}
+
+func (Noop) Stats() Stats {
+	return Stats{}
+}
+
+func (Noop) Close() error {
+	return nil
+}
@@ -0,0 +1,65 @@
+package cache
+
+// Decoded values are shared between every caller that reads the same entry,
+// so they must be treated as read-only.
+type DecodedStore interface {
+	GetDecoded(key string, raw []byte) (any, bool)
+	SetDecoded(key string, raw []byte, value any)
+}
+
+type Typed[T any] struct {
+	store  Store
+	decode func([]byte) (T, error)
+}
+
+func NewTyped[T any](store Store, decode func([]byte) (T, error)) *Typed[T] {
+	return &Typed[T]{
+		store:  store,
+		decode: decode,
+	}
+}
+
+func (t *Typed[T]) Get(key string) (T, bool, error) {
+	raw, ok := t.store.Get(key)
+
+	if !ok {
+		var zero T
+		return zero, false, nil
+	}
+
+	value, err := t.Decode(key, raw)
+
+	return value, true, err
+}
+
+func (t *Typed[T]) Decode(key string, raw []byte) (T, error) {
+	ds, ok := t.store.(DecodedStore)
+
+	if ok {
+		if value, found := ds.GetDecoded(key, raw); found {
+			if typed, ok := value.(T); ok {
+				return typed, nil
+			}
+		}
+	}
+
+	value, err := t.decode(raw)
+
+	if err != nil {
+		return value, err
+	}
+
+	if ok {
+		ds.SetDecoded(key, raw, value)
+	}
+
+	return value, nil
+}
+
+func sameBytes(a []byte, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	return len(a) == 0 || &a[0] == &b[0]
+}
@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTypedDecodesOnce(t *testing.T) {
+	disk, err := NewDiskStore(t.TempDir(), time.Minute)
+
+	if err != nil {
+		t.Fatalf("Unable to create disk store: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		store   Store
+		replace bool
+		decodes int
+	}{
+		{
+			name:    "memory",
+			store:   NewCache(time.Minute),
+			decodes: 1,
+		},
+		{
+			name:    "memory with replaced entry",
+			store:   NewCache(time.Minute),
+			replace: true,
+			decodes: 2,
+		},
+		{
+			name:    "layered",
+			store:   NewLayered(NewCache(time.Minute), NewCache(time.Minute)),
+			decodes: 1,
+		},
+		{
+			name:    "disk",
+			store:   disk,
+			decodes: 3,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			defer testCase.store.Close()
+
+			decodes := 0
+
+			typed := NewTyped(testCase.store, func(raw []byte) (int, error) {
+				decodes++
+				return strconv.Atoi(string(raw))
+			})
+
+			testCase.store.Add("answer", []byte("42"))
+
+			for i := 0; i < 3; i++ {
+				if i == 2 && testCase.replace {
+					testCase.store.Add("answer", []byte("42"))
+				}
+
+				value, ok, err := typed.Get("answer")
+
+				if !ok || err != nil || value != 42 {
+					t.Fatalf("Unexpected lookup. Got %v, %v and %v", value, ok, err)
+				}
+			}
+
+			if decodes != testCase.decodes {
+				t.Errorf("Decode count did not match. Got %v wanted %v", decodes, testCase.decodes)
+			}
+		})
+	}
+}
+
+func TestTypedMiss(t *testing.T) {
+	store := NewCache(time.Minute)
+	defer store.Close()
+
+	typed := NewTyped(store, func(raw []byte) (int, error) {
+		return strconv.Atoi(string(raw))
+	})
+
+	if _, ok, err := typed.Get("missing"); ok || err != nil {
+		t.Errorf("Expected a miss, got %v and %v", ok, err)
+	}
+}
+
+func TestTypedReusesValueForEqualBytes(t *testing.T) {
+	store := NewCache(time.Minute)
+	defer store.Close()
+
+	store.Add("answer", []byte("42"))
+	store.SetDecoded("answer", []byte("42"), 42)
+
+	cases := []struct {
+		name     string
+		raw      []byte
+		expected bool
+	}{
+		{name: "equal bytes", raw: []byte("42"), expected: true},
+		{name: "different bytes", raw: []byte("43"), expected: false},
+		{name: "different length", raw: []byte("420"), expected: false},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, found := store.GetDecoded("answer", testCase.raw); found != testCase.expected {
+				t.Errorf("Decoded lookup did not match. Got %v wanted %v", found, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestDecodedValuesAreBounded(t *testing.T) {
+	cases := []struct {
+		name     string
+		opts     []Option
+		expected []bool
+	}{
+		{name: "oldest decoded value dropped", opts: []Option{WithMaxDecoded(2)}, expected: []bool{false, true, true}},
+		{name: "decoding disabled", opts: []Option{WithMaxDecoded(0)}, expected: []bool{false, false, false}},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := NewCache(time.Minute, testCase.opts...)
+			defer store.Close()
+
+			keys := []string{"a", "b", "c"}
+
+			for i, key := range keys {
+				store.Add(key, []byte(key))
+				store.SetDecoded(key, []byte(key), i)
+			}
+
+			for i, key := range keys {
+				if _, found := store.GetDecoded(key, []byte(key)); found != testCase.expected[i] {
+					t.Errorf("Decoded %v found did not match. Got %v wanted %v", key, found, testCase.expected[i])
+				}
+			}
+
+			if _, ok := store.Get("a"); !ok {
+				t.Errorf("Expected the raw entry to stay cached")
+			}
+		})
+	}
+}
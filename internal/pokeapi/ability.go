@@ -0,0 +1,40 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+)
+
+type AbilityPokemon struct {
+	IsHidden bool             `json:"is_hidden"`
+	Slot     int              `json:"slot"`
+	Pokemon  NamedAPIResource `json:"pokemon"`
+}
+
+type Ability struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	IsMainSeries  bool             `json:"is_main_series"`
+	Generation    NamedAPIResource `json:"generation"`
+	EffectEntries []VerboseEffect  `json:"effect_entries"`
+	Pokemon       []AbilityPokemon `json:"pokemon"`
+	Stale         bool             `json:"-"`
+}
+
+func (c *Client) GetAbility(name string) (Ability, error) {
+	return c.GetAbilityContext(context.Background(), name)
+}
+
+func (c *Client) GetAbilityContext(ctx context.Context, name string) (Ability, error) {
+	path := fmt.Sprintf("ability/%v", name)
+
+	ability, stale, err := get[Ability](ctx, c, path, resourceTTL)
+
+	ability.Stale = stale
+
+	return ability, err
+}
+
+func (a Ability) Effect(language string) string {
+	return effectText(a.EffectEntries, language, nil)
+}
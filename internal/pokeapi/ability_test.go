@@ -0,0 +1,62 @@
+package pokeapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAbility(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ability/static" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write([]byte(`{
+			"id": 9,
+			"name": "static",
+			"is_main_series": true,
+			"effect_entries": [
+				{"effect": "Eine Attacke...", "short_effect": "Kann bei Berührung paralysieren.", "language": {"name": "de"}},
+				{"effect": "Whenever a move makes contact with this Pokemon, the move's user has a 30% chance of being paralyzed.", "short_effect": "Has a 30% chance of paralyzing attacking Pokemon on contact.", "language": {"name": "en"}}
+			],
+			"pokemon": [
+				{"is_hidden": false, "slot": 1, "pokemon": {"name": "pikachu"}},
+				{"is_hidden": true, "slot": 3, "pokemon": {"name": "electabuzz"}}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+
+	ability, err := client.GetAbility("static")
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		language string
+		expected string
+	}{
+		{language: "en", expected: "Has a 30% chance of paralyzing attacking Pokemon on contact."},
+		{language: "de", expected: "Kann bei Berührung paralysieren."},
+		{language: "fr", expected: ""},
+	}
+
+	for _, testCase := range cases {
+		if got := ability.Effect(testCase.language); got != testCase.expected {
+			t.Errorf("Effect did not match. Got %v wanted %v", got, testCase.expected)
+		}
+	}
+
+	if len(ability.Pokemon) != 2 || ability.Pokemon[0].IsHidden || !ability.Pokemon[1].IsHidden || ability.Pokemon[1].Pokemon.Name != "electabuzz" {
+		t.Errorf("Unexpected pokemon: %+v", ability.Pokemon)
+	}
+
+	if _, err := client.GetAbility("unknown"); err == nil {
+		t.Errorf("Expected an error for an unknown ability")
+	}
+}
@@ -0,0 +1,320 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/logan-bobo/pokedex-cli/internal/cache"
+)
+
+const DefaultBaseURL = "https://pokeapi.co/api/v2/"
+
+const DefaultUserAgent = "pokedex-cli"
+
+const defaultTimeout = 30 * time.Second
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	userAgent  string
+	timeout    time.Duration
+	retries    int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	debug      io.Writer
+	limiter    *limiter
+	store      cache.Store
+	inflight   flightGroup
+}
+
+type ClientOption func(*Client)
+
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+
+		c.baseURL = baseURL
+	}
+}
+
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+func WithDebug(w io.Writer) ClientOption {
+	return func(c *Client) {
+		c.debug = w
+	}
+}
+
+func WithStore(store cache.Store) ClientOption {
+	return func(c *Client) {
+		c.store = store
+	}
+}
+
+func NewClient(opts ...ClientOption) *Client {
+	c := Client{
+		baseURL:    DefaultBaseURL,
+		httpClient: &http.Client{},
+		userAgent:  DefaultUserAgent,
+		timeout:    defaultTimeout,
+		retries:    defaultRetries,
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+		limiter:    newLimiter(defaultRateLimit, defaultBurst),
+		store:      cache.Noop{},
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &c
+}
+
+func (c *Client) Store() cache.Store {
+	return c.store
+}
+
+type response struct {
+	body       []byte
+	status     int
+	validators cache.Validators
+	retryAfter time.Duration
+}
+
+func parseValidators(header http.Header) cache.Validators {
+	validators := cache.Validators{
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+	}
+
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(directive), "max-age=")
+
+		if !ok {
+			continue
+		}
+
+		seconds, err := strconv.Atoi(value)
+
+		if err == nil && seconds > 0 {
+			validators.MaxAge = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return validators
+}
+
+func freshFor(ttl time.Duration, validators cache.Validators) time.Duration {
+	if ttl == cache.NoExpiry || validators.MaxAge == 0 {
+		return ttl
+	}
+
+	return validators.MaxAge
+}
+
+func (c *Client) debugf(format string, args ...any) {
+	if c.debug != nil {
+		fmt.Fprintf(c.debug, format+"\n", args...)
+	}
+}
+
+func (c *Client) fetch(ctx context.Context, requestURL string, validators cache.Validators) (response, error) {
+	attempts := max(c.retries, 0) + 1
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.fetchOnce(ctx, requestURL, validators)
+
+		if err != nil {
+			c.debugf("GET %v attempt %v/%v failed: %v", requestURL, attempt, attempts, err)
+		} else {
+			c.debugf("GET %v attempt %v/%v: %v", requestURL, attempt, attempts, resp.status)
+		}
+
+		if attempt >= attempts || !retryable(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay, ok := c.backoff(attempt, resp.retryAfter)
+
+		if !ok {
+			c.debugf("GET %v not retrying, server asked to wait %v", requestURL, delay)
+			return resp, err
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return resp, err
+		}
+	}
+}
+
+func (c *Client) fetchOnce(ctx context.Context, requestURL string, validators cache.Validators) (response, error) {
+	if err := c.limiter.wait(ctx); err != nil {
+		return response{}, err
+	}
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+
+	if err != nil {
+		return response{}, err
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return response{}, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+
+	resp.Body.Close()
+
+	result := response{
+		body:       body,
+		status:     resp.StatusCode,
+		validators: parseValidators(resp.Header),
+		retryAfter: parseRetryAfter(resp.Header, time.Now()),
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		return result, nil
+	}
+
+	if resp.StatusCode > 299 {
+		return result, &HTTPError{Status: resp.StatusCode, URL: requestURL}
+	}
+
+	return result, nil
+}
+
+func (c *Client) fetchAndStore(ctx context.Context, requestURL string, ttl time.Duration, stale []byte) (response, error) {
+	return c.inflight.do(ctx, requestURL, func(ctx context.Context) (response, error) {
+		validators := cache.Validators{}
+
+		if stale != nil {
+			validators, _ = cache.GetValidators(c.store, requestURL)
+		}
+
+		resp, err := c.fetch(ctx, requestURL, validators)
+
+		if err != nil {
+			return resp, err
+		}
+
+		if resp.status == http.StatusNotModified {
+			resp.body = stale
+
+			if resp.validators.ETag == "" {
+				resp.validators.ETag = validators.ETag
+			}
+
+			if resp.validators.LastModified == "" {
+				resp.validators.LastModified = validators.LastModified
+			}
+
+			if resp.validators.MaxAge == 0 {
+				resp.validators.MaxAge = validators.MaxAge
+			}
+		}
+
+		cache.AddWithValidators(c.store, requestURL, resp.body, freshFor(ttl, resp.validators), resp.validators)
+
+		return resp, nil
+	})
+}
+
+func (c *Client) CacheKey(path string) string {
+	return fmt.Sprintf("%v%v", c.baseURL, path)
+}
+
+func (c *Client) getAPIEndpoint(ctx context.Context, path string, ttl time.Duration) ([]byte, bool, error) {
+	requestURL := c.CacheKey(path)
+
+	data, freshness, cacheObj := cache.GetStale(c.store, requestURL)
+
+	if cacheObj && freshness == cache.Fresh {
+		return data, false, nil
+	}
+
+	if cacheObj && freshness == cache.StaleWhileRevalidate {
+		go c.fetchAndStore(context.Background(), requestURL, ttl, data)
+
+		return data, true, nil
+	}
+
+	resp, err := c.fetchAndStore(ctx, requestURL, ttl, data)
+
+	if err != nil {
+		if cacheObj && freshness == cache.StaleIfError && !errors.Is(err, context.Canceled) && (resp.status == 0 || resp.status > 499) {
+			return data, true, nil
+		}
+
+		return []byte{}, false, err
+	}
+
+	return resp.body, false, nil
+}
+
+func decodeJSON[T any](body []byte) (T, error) {
+	var value T
+
+	err := json.Unmarshal(body, &value)
+
+	return value, err
+}
+
+func decode[T any](c *Client, path string, body []byte) (T, error) {
+	value, err := cache.NewTyped(c.store, decodeJSON[T]).Decode(c.CacheKey(path), body)
+
+	if err != nil {
+		return value, &DecodeError{Resource: path, Err: err}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,495 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/logan-bobo/pokedex-cli/internal/cache"
+)
+
+func TestParseValidators(t *testing.T) {
+	cases := []struct {
+		name     string
+		header   http.Header
+		expected cache.Validators
+	}{
+		{
+			name:     "no validators",
+			header:   http.Header{},
+			expected: cache.Validators{},
+		},
+		{
+			name: "all validators",
+			header: http.Header{
+				"Etag":          {`W/"abc"`},
+				"Last-Modified": {"Mon, 02 Jan 2006 15:04:05 GMT"},
+				"Cache-Control": {"public, max-age=86400, s-maxage=86400"},
+			},
+			expected: cache.Validators{
+				ETag:         `W/"abc"`,
+				LastModified: "Mon, 02 Jan 2006 15:04:05 GMT",
+				MaxAge:       24 * time.Hour,
+			},
+		},
+		{
+			name: "invalid max age",
+			header: http.Header{
+				"Cache-Control": {"max-age=soon"},
+			},
+			expected: cache.Validators{},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseValidators(testCase.header)
+
+			if got != testCase.expected {
+				t.Errorf("Validators did not match. Got %v wanted %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestConditionalRevalidation(t *testing.T) {
+	const etag = `"v1"`
+
+	requests := 0
+	conditional := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "max-age=60")
+
+		if r.Header.Get("If-None-Match") == etag {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Write([]byte("somedata"))
+	}))
+	defer server.Close()
+
+	store := cache.NewCache(time.Minute)
+	defer store.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithStore(store))
+	requestURL := client.CacheKey("pokemon/pikachu")
+
+	resp, err := client.fetchAndStore(context.Background(), requestURL, time.Minute, nil)
+
+	if err != nil || string(resp.body) != "somedata" {
+		t.Fatalf("Unexpected first response. Got %v and %v", string(resp.body), err)
+	}
+
+	if validators, ok := cache.GetValidators(store, requestURL); !ok || validators.ETag != etag {
+		t.Fatalf("Expected ETag to be cached, got %v", validators)
+	}
+
+	resp, err = client.fetchAndStore(context.Background(), requestURL, time.Minute, []byte("somedata"))
+
+	if err != nil {
+		t.Fatalf("Unexpected error on revalidation: %v", err)
+	}
+
+	if resp.status != http.StatusNotModified || string(resp.body) != "somedata" {
+		t.Errorf("Expected a 304 serving the cached body. Got %v with %v", resp.status, string(resp.body))
+	}
+
+	if requests != 2 || conditional != 1 {
+		t.Errorf("Expected one conditional request, got %v requests and %v conditional", requests, conditional)
+	}
+
+	if data, ok := store.Get(requestURL); !ok || string(data) != "somedata" {
+		t.Errorf("Expected revalidated entry to be fresh in the cache")
+	}
+}
+
+func TestConditionalRevalidationOfExpiredEntry(t *testing.T) {
+	const etag = `"v1"`
+
+	cases := []struct {
+		name        string
+		status      int
+		conditional int
+		err         bool
+	}{
+		{name: "not modified", status: http.StatusNotModified, conditional: 1},
+		{name: "server error", status: http.StatusServiceUnavailable, conditional: 1, err: true},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			requests := 0
+			conditional := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+
+				w.Header().Set("ETag", etag)
+				w.Header().Set("Cache-Control", "max-age=60")
+
+				if r.Header.Get("If-None-Match") == etag {
+					conditional++
+					w.WriteHeader(testCase.status)
+					return
+				}
+
+				w.Write([]byte(`{"name":"canalave-city-area"}`))
+			}))
+			defer server.Close()
+
+			clock := newTestClock()
+
+			store := cache.NewCache(time.Minute, cache.WithClock(clock))
+			defer store.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithStore(store), WithRetries(0))
+
+			if _, err := client.ExploreLocation("canalave-city-area"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			clock.Advance(2 * time.Minute)
+
+			loc, err := client.ExploreLocation("canalave-city-area")
+
+			if requests != 2 || conditional != testCase.conditional {
+				t.Errorf("Expected a conditional request for the expired entry, got %v requests and %v conditional", requests, conditional)
+			}
+
+			if testCase.err {
+				if err == nil {
+					t.Errorf("Expected an error instead of the expired entry, got %v", loc.Name)
+				}
+
+				return
+			}
+
+			if err != nil || loc.Name != "canalave-city-area" || loc.Stale {
+				t.Errorf("Expected the revalidated location. Got %v stale=%v and %v", loc.Name, loc.Stale, err)
+			}
+		})
+	}
+}
+
+func TestConditionalRevalidationAfterRestart(t *testing.T) {
+	const etag = `"v1"`
+
+	requests := 0
+	conditional := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "max-age=60")
+
+		if r.Header.Get("If-None-Match") == etag {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Write([]byte(`{"name":"canalave-city-area"}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	clock := newTestClock()
+
+	open := func() *cache.Layered {
+		store, err := cache.NewPersistentCache(time.Minute, dir, cache.WithClock(clock), cache.WithReapInterval(0), cache.WithStaleIfError(time.Hour))
+
+		if err != nil {
+			t.Fatalf("Unable to create persistent cache: %v", err)
+		}
+
+		return store
+	}
+
+	store := open()
+
+	if _, err := NewClient(WithBaseURL(server.URL), WithStore(store)).ExploreLocation("canalave-city-area"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	store.Close()
+
+	clock.Advance(10 * time.Minute)
+
+	reloaded := open()
+	defer reloaded.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithStore(reloaded))
+
+	loc, err := client.ExploreLocation("canalave-city-area")
+
+	if err != nil {
+		t.Fatalf("Unexpected error on revalidation: %v", err)
+	}
+
+	if loc.Name != "canalave-city-area" || loc.Stale {
+		t.Errorf("Expected the revalidated location. Got %v stale=%v", loc.Name, loc.Stale)
+	}
+
+	if requests != 2 || conditional != 1 {
+		t.Errorf("Expected one conditional request after the restart, got %v requests and %v conditional", requests, conditional)
+	}
+
+	if _, ok := reloaded.Get(client.CacheKey("location-area/canalave-city-area")); !ok {
+		t.Errorf("Expected the revalidated entry to be fresh in the cache")
+	}
+}
+
+func TestClientOptions(t *testing.T) {
+	cases := []struct {
+		name      string
+		prefix    string
+		opts      []ClientOption
+		userAgent string
+		path      string
+	}{
+		{
+			name:      "defaults",
+			userAgent: DefaultUserAgent,
+			path:      "/pokemon/pikachu",
+		},
+		{
+			name:      "custom user agent",
+			opts:      []ClientOption{WithUserAgent("pokedex-test")},
+			userAgent: "pokedex-test",
+			path:      "/pokemon/pikachu",
+		},
+		{
+			name:      "base URL with a path prefix",
+			prefix:    "/api/v2",
+			userAgent: DefaultUserAgent,
+			path:      "/api/v2/pokemon/pikachu",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var userAgent, path string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				userAgent = r.Header.Get("User-Agent")
+				path = r.URL.Path
+
+				w.Write([]byte(`{"name":"pikachu"}`))
+			}))
+			defer server.Close()
+
+			opts := append([]ClientOption{WithBaseURL(server.URL + testCase.prefix)}, testCase.opts...)
+
+			client := NewClient(opts...)
+
+			if _, err := client.GetPokemon("pikachu"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if userAgent != testCase.userAgent {
+				t.Errorf("User agent did not match. Got %v wanted %v", userAgent, testCase.userAgent)
+			}
+
+			if path != testCase.path {
+				t.Errorf("Path did not match. Got %v wanted %v", path, testCase.path)
+			}
+		})
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(WithBaseURL(server.URL), WithTimeout(10*time.Millisecond))
+
+	if _, err := client.GetPokemon("pikachu"); err == nil {
+		t.Errorf("Expected the request to time out")
+	}
+}
+
+func TestClientContextCancel(t *testing.T) {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetPokemonContext(ctx, "pikachu")
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the request to be cancelled, got %v", err)
+	}
+}
+
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+type testTicker struct{}
+
+func newTestClock() *testClock {
+	return &testClock{now: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *testClock) NewTicker(d time.Duration) cache.Ticker {
+	return testTicker{}
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func (testTicker) Chan() <-chan time.Time {
+	return nil
+}
+
+func (testTicker) Stop() {}
+
+func TestServeStale(t *testing.T) {
+	const path = "location-area/canalave-city-area"
+
+	cases := []struct {
+		name      string
+		advance   time.Duration
+		status    int
+		hangup    bool
+		body      string
+		stale     bool
+		err       error
+		refreshed bool
+	}{
+		{
+			name:      "stale while revalidate",
+			advance:   90 * time.Second,
+			status:    http.StatusOK,
+			body:      "old",
+			stale:     true,
+			refreshed: true,
+		},
+		{
+			name:    "server error",
+			advance: 10 * time.Minute,
+			status:  http.StatusServiceUnavailable,
+			body:    "old",
+			stale:   true,
+		},
+		{
+			name:    "transport error",
+			advance: 10 * time.Minute,
+			hangup:  true,
+			body:    "old",
+			stale:   true,
+		},
+		{
+			name:    "not found",
+			advance: 10 * time.Minute,
+			status:  http.StatusNotFound,
+			err:     ErrNotFound,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if testCase.hangup {
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				}
+
+				w.WriteHeader(testCase.status)
+				w.Write([]byte(`{"name":"new"}`))
+			}))
+			defer server.Close()
+
+			clock := newTestClock()
+
+			store := cache.NewCache(
+				time.Minute,
+				cache.WithClock(clock),
+				cache.WithReapInterval(0),
+				cache.WithStaleWhileRevalidate(5*time.Minute),
+				cache.WithStaleIfError(time.Hour),
+			)
+			defer store.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithStore(store), WithRetries(0))
+
+			store.AddWithTTL(client.CacheKey(path), []byte(`{"name":"old"}`), time.Minute)
+
+			clock.Advance(testCase.advance)
+
+			loc, err := client.ExploreLocation("canalave-city-area")
+
+			if testCase.err != nil {
+				if !errors.Is(err, testCase.err) {
+					t.Errorf("Expected %v, got %v", testCase.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if loc.Name != testCase.body || loc.Stale != testCase.stale {
+				t.Errorf("Unexpected location. Got %v stale=%v wanted %v stale=%v", loc.Name, loc.Stale, testCase.body, testCase.stale)
+			}
+
+			if !testCase.refreshed {
+				return
+			}
+
+			deadline := time.Now().Add(time.Second)
+
+			for time.Now().Before(deadline) {
+				if data, ok := store.Get(client.CacheKey(path)); ok && string(data) == `{"name":"new"}` {
+					return
+				}
+
+				time.Sleep(time.Millisecond)
+			}
+
+			t.Errorf("Expected the stale entry to be refreshed in the background")
+		})
+	}
+}
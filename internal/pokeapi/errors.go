@@ -0,0 +1,50 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var ErrNotFound = errors.New("resource not found")
+
+var ErrNoEvolutionChain = errors.New("species has no evolution chain")
+
+type HTTPError struct {
+	Status int
+	URL    string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("Non 200 status code, got %v on path %v", e.Status, e.URL)
+}
+
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrNotFound && e.Status == http.StatusNotFound
+}
+
+type MoveError struct {
+	Name string
+	Err  error
+}
+
+func (e *MoveError) Error() string {
+	return fmt.Sprintf("Unable to fetch move %v: %v", e.Name, e.Err)
+}
+
+func (e *MoveError) Unwrap() error {
+	return e.Err
+}
+
+type DecodeError struct {
+	Resource string
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("Unable to decode %v: %v", e.Resource, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
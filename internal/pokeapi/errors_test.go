@@ -0,0 +1,81 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTypedErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   int
+		body     string
+		notFound bool
+		httpErr  int
+		decode   bool
+	}{
+		{
+			name:     "not found",
+			status:   http.StatusNotFound,
+			notFound: true,
+			httpErr:  http.StatusNotFound,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			httpErr: http.StatusInternalServerError,
+		},
+		{
+			name:   "bad json",
+			status: http.StatusOK,
+			body:   `{"name":`,
+			decode: true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(testCase.status)
+				w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithRetries(0))
+
+			_, err := client.GetPokemon("pikachu")
+
+			if err == nil {
+				t.Fatalf("Expected an error")
+			}
+
+			if errors.Is(err, ErrNotFound) != testCase.notFound {
+				t.Errorf("ErrNotFound did not match. Got %v wanted %v", errors.Is(err, ErrNotFound), testCase.notFound)
+			}
+
+			var httpErr *HTTPError
+
+			if errors.As(err, &httpErr) {
+				if httpErr.Status != testCase.httpErr || httpErr.URL != server.URL+"/pokemon/pikachu" {
+					t.Errorf("Unexpected HTTPError. Got %v on %v wanted %v", httpErr.Status, httpErr.URL, testCase.httpErr)
+				}
+			} else if testCase.httpErr != 0 {
+				t.Errorf("Expected an HTTPError, got %v", err)
+			}
+
+			var decodeErr *DecodeError
+			var syntaxErr *json.SyntaxError
+
+			if errors.As(err, &decodeErr) != testCase.decode {
+				t.Errorf("DecodeError did not match. Got %v wanted %v", err, testCase.decode)
+			}
+
+			if testCase.decode && (decodeErr.Resource != "pokemon/pikachu" || !errors.As(err, &syntaxErr)) {
+				t.Errorf("Expected the decode error to name the resource and wrap the JSON error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type EvolutionChain struct {
+	ID              int              `json:"id"`
+	BabyTriggerItem NamedAPIResource `json:"baby_trigger_item"`
+	Chain           ChainLink        `json:"chain"`
+	Stale           bool             `json:"-"`
+}
+
+type ChainLink struct {
+	IsBaby           bool              `json:"is_baby"`
+	Species          NamedAPIResource  `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+type EvolutionDetail struct {
+	Trigger               NamedAPIResource `json:"trigger"`
+	Item                  NamedAPIResource `json:"item"`
+	HeldItem              NamedAPIResource `json:"held_item"`
+	KnownMove             NamedAPIResource `json:"known_move"`
+	KnownMoveType         NamedAPIResource `json:"known_move_type"`
+	Location              NamedAPIResource `json:"location"`
+	PartySpecies          NamedAPIResource `json:"party_species"`
+	PartyType             NamedAPIResource `json:"party_type"`
+	TradeSpecies          NamedAPIResource `json:"trade_species"`
+	Gender                *int             `json:"gender"`
+	MinLevel              int              `json:"min_level"`
+	MinHappiness          int              `json:"min_happiness"`
+	MinBeauty             int              `json:"min_beauty"`
+	MinAffection          int              `json:"min_affection"`
+	RelativePhysicalStats *int             `json:"relative_physical_stats"`
+	TimeOfDay             string           `json:"time_of_day"`
+	NeedsOverworldRain    bool             `json:"needs_overworld_rain"`
+	TurnUpsideDown        bool             `json:"turn_upside_down"`
+}
+
+func (c *Client) GetEvolutionChain(id int) (EvolutionChain, error) {
+	return c.GetEvolutionChainContext(context.Background(), id)
+}
+
+func (c *Client) GetEvolutionChainContext(ctx context.Context, id int) (EvolutionChain, error) {
+	path := fmt.Sprintf("evolution-chain/%v", id)
+
+	chain, stale, err := get[EvolutionChain](ctx, c, path, resourceTTL)
+
+	chain.Stale = stale
+
+	return chain, err
+}
+
+func (c *Client) GetSpeciesEvolutionChain(species string) (EvolutionChain, error) {
+	return c.GetSpeciesEvolutionChainContext(context.Background(), species)
+}
+
+func (c *Client) GetSpeciesEvolutionChainContext(ctx context.Context, species string) (EvolutionChain, error) {
+	found, err := c.GetPokemonSpeciesContext(ctx, species)
+
+	if err != nil {
+		return EvolutionChain{}, err
+	}
+
+	if found.EvolutionChain.URL == "" {
+		return EvolutionChain{}, ErrNoEvolutionChain
+	}
+
+	path, err := c.resourceKey(NamedAPIResource{URL: found.EvolutionChain.URL})
+
+	if err != nil {
+		return EvolutionChain{}, err
+	}
+
+	chain, stale, err := get[EvolutionChain](ctx, c, path, resourceTTL)
+
+	chain.Stale = stale || found.Stale
+
+	return chain, err
+}
+
+func (d EvolutionDetail) String() string {
+	parts := []string{}
+
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel > 0 {
+			parts = append(parts, fmt.Sprintf("level %v", d.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case "use-item":
+		parts = append(parts, fmt.Sprintf("use %v", d.Item.Name))
+	case "trade":
+		if d.TradeSpecies.Name != "" {
+			parts = append(parts, fmt.Sprintf("trade for %v", d.TradeSpecies.Name))
+		} else {
+			parts = append(parts, "trade")
+		}
+	default:
+		parts = append(parts, d.Trigger.Name)
+	}
+
+	if d.HeldItem.Name != "" {
+		parts = append(parts, fmt.Sprintf("holding %v", d.HeldItem.Name))
+	}
+
+	if d.MinHappiness > 0 {
+		parts = append(parts, fmt.Sprintf("happiness %v+", d.MinHappiness))
+	}
+
+	if d.MinAffection > 0 {
+		parts = append(parts, fmt.Sprintf("affection %v+", d.MinAffection))
+	}
+
+	if d.MinBeauty > 0 {
+		parts = append(parts, fmt.Sprintf("beauty %v+", d.MinBeauty))
+	}
+
+	if d.KnownMove.Name != "" {
+		parts = append(parts, fmt.Sprintf("knowing %v", d.KnownMove.Name))
+	}
+
+	if d.KnownMoveType.Name != "" {
+		parts = append(parts, fmt.Sprintf("knowing a %v move", d.KnownMoveType.Name))
+	}
+
+	if d.Location.Name != "" {
+		parts = append(parts, fmt.Sprintf("at %v", d.Location.Name))
+	}
+
+	if d.TimeOfDay != "" {
+		parts = append(parts, fmt.Sprintf("during the %v", d.TimeOfDay))
+	}
+
+	if d.Gender != nil {
+		switch *d.Gender {
+		case 1:
+			parts = append(parts, "female only")
+		case 2:
+			parts = append(parts, "male only")
+		}
+	}
+
+	if d.RelativePhysicalStats != nil {
+		switch *d.RelativePhysicalStats {
+		case 1:
+			parts = append(parts, "attack higher than defense")
+		case -1:
+			parts = append(parts, "attack lower than defense")
+		case 0:
+			parts = append(parts, "attack equal to defense")
+		}
+	}
+
+	if d.PartySpecies.Name != "" {
+		parts = append(parts, fmt.Sprintf("with %v in the party", d.PartySpecies.Name))
+	}
+
+	if d.PartyType.Name != "" {
+		parts = append(parts, fmt.Sprintf("with a %v type in the party", d.PartyType.Name))
+	}
+
+	if d.NeedsOverworldRain {
+		parts = append(parts, "while raining")
+	}
+
+	if d.TurnUpsideDown {
+		parts = append(parts, "holding the console upside down")
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func (l ChainLink) Trigger() string {
+	details := []string{}
+
+	for _, detail := range l.EvolutionDetails {
+		details = append(details, detail.String())
+	}
+
+	return strings.Join(details, " or ")
+}
@@ -0,0 +1,134 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const eeveeChainBody = `{
+	"id": 67,
+	"chain": {
+		"is_baby": false,
+		"species": {"name": "eevee"},
+		"evolution_details": [],
+		"evolves_to": [
+			{
+				"species": {"name": "vaporeon"},
+				"evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}, "gender": null, "min_level": null}],
+				"evolves_to": []
+			},
+			{
+				"species": {"name": "espeon"},
+				"evolution_details": [{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "day"}],
+				"evolves_to": []
+			},
+			{
+				"species": {"name": "leafeon"},
+				"evolution_details": [
+					{"trigger": {"name": "level-up"}, "location": {"name": "eterna-forest"}},
+					{"trigger": {"name": "use-item"}, "item": {"name": "leaf-stone"}}
+				],
+				"evolves_to": []
+			}
+		]
+	}
+}`
+
+func TestGetSpeciesEvolutionChain(t *testing.T) {
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon-species/eevee":
+			fmt.Fprintf(w, `{"name":"eevee","evolution_chain":{"url":"%v/evolution-chain/67/"}}`, server.URL)
+		case "/pokemon-species/missingno":
+			w.Write([]byte(`{"name":"missingno"}`))
+		case "/evolution-chain/67":
+			w.Write([]byte(eeveeChainBody))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+
+	chain, err := client.GetSpeciesEvolutionChain("eevee")
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if chain.ID != 67 || chain.Chain.Species.Name != "eevee" || len(chain.Chain.EvolvesTo) != 3 {
+		t.Fatalf("Unexpected chain: %+v", chain)
+	}
+
+	cases := []struct {
+		species string
+		trigger string
+	}{
+		{species: "vaporeon", trigger: "use water-stone"},
+		{species: "espeon", trigger: "level up, happiness 160+, during the day"},
+		{species: "leafeon", trigger: "level up, at eterna-forest or use leaf-stone"},
+	}
+
+	for i, testCase := range cases {
+		link := chain.Chain.EvolvesTo[i]
+
+		if link.Species.Name != testCase.species || link.Trigger() != testCase.trigger {
+			t.Errorf("Evolution did not match. Got %v (%v) wanted %v (%v)", link.Species.Name, link.Trigger(), testCase.species, testCase.trigger)
+		}
+	}
+
+	if _, err := client.GetSpeciesEvolutionChain("missingno"); !errors.Is(err, ErrNoEvolutionChain) {
+		t.Errorf("Expected an error for a species without an evolution chain")
+	}
+}
+
+func TestEvolutionDetailString(t *testing.T) {
+	female := 1
+	higher := 1
+
+	cases := []struct {
+		name     string
+		detail   EvolutionDetail
+		expected string
+	}{
+		{
+			name:     "level",
+			detail:   EvolutionDetail{Trigger: NamedAPIResource{Name: "level-up"}, MinLevel: 16},
+			expected: "level 16",
+		},
+		{
+			name:     "trade holding an item",
+			detail:   EvolutionDetail{Trigger: NamedAPIResource{Name: "trade"}, HeldItem: NamedAPIResource{Name: "metal-coat"}},
+			expected: "trade, holding metal-coat",
+		},
+		{
+			name:     "trade for a species",
+			detail:   EvolutionDetail{Trigger: NamedAPIResource{Name: "trade"}, TradeSpecies: NamedAPIResource{Name: "shelmet"}},
+			expected: "trade for shelmet",
+		},
+		{
+			name:     "gender and stats",
+			detail:   EvolutionDetail{Trigger: NamedAPIResource{Name: "level-up"}, MinLevel: 20, Gender: &female, RelativePhysicalStats: &higher},
+			expected: "level 20, female only, attack higher than defense",
+		},
+		{
+			name:     "other trigger",
+			detail:   EvolutionDetail{Trigger: NamedAPIResource{Name: "shed"}},
+			expected: "shed",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := testCase.detail.String(); got != testCase.expected {
+				t.Errorf("Detail did not match. Got %v wanted %v", got, testCase.expected)
+			}
+		})
+	}
+}
@@ -1,159 +1,99 @@
 package pokeapi
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
+	"time"
 
 	"github.com/logan-bobo/pokedex-cli/internal/cache"
 )
 
-const endpoint = "https://pokeapi.co/api/v2/"
-
-type Locations struct {
-	Count    int    `json:"count"`
-	Next     string `json:"next"`
-	Previous any    `json:"previous"`
-	Results  []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"results"`
-}
+const (
+	listTTL     = 72 * time.Hour
+	locationTTL = 72 * time.Hour
+	pokemonTTL  = cache.NoExpiry
+	resourceTTL = cache.NoExpiry
+)
 
 type LocationData struct {
 	EncounterMethodRates []struct {
-		EncounterMethod struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"encounter_method"`
-		VersionDetails []struct {
-			Rate    int `json:"rate"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
+		EncounterMethod NamedAPIResource `json:"encounter_method"`
+		VersionDetails  []struct {
+			Rate    int              `json:"rate"`
+			Version NamedAPIResource `json:"version"`
 		} `json:"version_details"`
 	} `json:"encounter_method_rates"`
-	GameIndex int `json:"game_index"`
-	ID        int `json:"id"`
-	Location  struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"location"`
-	Name  string `json:"name"`
-	Names []struct {
-		Language struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"language"`
-		Name string `json:"name"`
+	GameIndex int              `json:"game_index"`
+	ID        int              `json:"id"`
+	Location  NamedAPIResource `json:"location"`
+	Name      string           `json:"name"`
+	Names     []struct {
+		Language NamedAPIResource `json:"language"`
+		Name     string           `json:"name"`
 	} `json:"names"`
 	PokemonEncounters []struct {
-		Pokemon struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"pokemon"`
+		Pokemon        NamedAPIResource `json:"pokemon"`
 		VersionDetails []struct {
 			EncounterDetails []struct {
-				Chance          int   `json:"chance"`
-				ConditionValues []any `json:"condition_values"`
-				MaxLevel        int   `json:"max_level"`
-				Method          struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"method"`
-				MinLevel int `json:"min_level"`
+				Chance          int              `json:"chance"`
+				ConditionValues []any            `json:"condition_values"`
+				MaxLevel        int              `json:"max_level"`
+				Method          NamedAPIResource `json:"method"`
+				MinLevel        int              `json:"min_level"`
 			} `json:"encounter_details"`
-			MaxChance int `json:"max_chance"`
-			Version   struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
+			MaxChance int              `json:"max_chance"`
+			Version   NamedAPIResource `json:"version"`
 		} `json:"version_details"`
 	} `json:"pokemon_encounters"`
+	Stale bool `json:"-"`
 }
 
 type Pokemon struct {
 	Abilities []struct {
-		Ability struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"ability"`
-		IsHidden bool `json:"is_hidden"`
-		Slot     int  `json:"slot"`
+		Ability  NamedAPIResource `json:"ability"`
+		IsHidden bool             `json:"is_hidden"`
+		Slot     int              `json:"slot"`
 	} `json:"abilities"`
 	BaseExperience int `json:"base_experience"`
 	Cries          struct {
 		Latest string `json:"latest"`
 		Legacy string `json:"legacy"`
 	} `json:"cries"`
-	Forms []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"forms"`
+	Forms       []NamedAPIResource `json:"forms"`
 	GameIndices []struct {
-		GameIndex int `json:"game_index"`
-		Version   struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"version"`
+		GameIndex int              `json:"game_index"`
+		Version   NamedAPIResource `json:"version"`
 	} `json:"game_indices"`
 	Height    int `json:"height"`
 	HeldItems []struct {
-		Item struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"item"`
+		Item           NamedAPIResource `json:"item"`
 		VersionDetails []struct {
-			Rarity  int `json:"rarity"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
+			Rarity  int              `json:"rarity"`
+			Version NamedAPIResource `json:"version"`
 		} `json:"version_details"`
 	} `json:"held_items"`
 	ID                     int    `json:"id"`
 	IsDefault              bool   `json:"is_default"`
 	LocationAreaEncounters string `json:"location_area_encounters"`
 	Moves                  []struct {
-		Move struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"move"`
+		Move                NamedAPIResource `json:"move"`
 		VersionGroupDetails []struct {
-			LevelLearnedAt  int `json:"level_learned_at"`
-			MoveLearnMethod struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"move_learn_method"`
-			VersionGroup struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version_group"`
+			LevelLearnedAt  int              `json:"level_learned_at"`
+			MoveLearnMethod NamedAPIResource `json:"move_learn_method"`
+			VersionGroup    NamedAPIResource `json:"version_group"`
 		} `json:"version_group_details"`
 	} `json:"moves"`
 	Name          string `json:"name"`
 	Order         int    `json:"order"`
 	PastAbilities []any  `json:"past_abilities"`
 	PastTypes     []struct {
-		Generation struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"generation"`
-		Types []struct {
-			Slot int `json:"slot"`
-			Type struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"type"`
+		Generation NamedAPIResource `json:"generation"`
+		Types      []struct {
+			Slot int              `json:"slot"`
+			Type NamedAPIResource `json:"type"`
 		} `json:"types"`
 	} `json:"past_types"`
-	Species struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"species"`
+	Species NamedAPIResource `json:"species"`
 	Sprites struct {
 		BackDefault      string `json:"back_default"`
 		BackFemale       any    `json:"back_female"`
@@ -341,118 +281,70 @@ type Pokemon struct {
 		} `json:"versions"`
 	} `json:"sprites"`
 	Stats []struct {
-		BaseStat int `json:"base_stat"`
-		Effort   int `json:"effort"`
-		Stat     struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"stat"`
+		BaseStat int              `json:"base_stat"`
+		Effort   int              `json:"effort"`
+		Stat     NamedAPIResource `json:"stat"`
 	} `json:"stats"`
 	Types []struct {
-		Slot int `json:"slot"`
-		Type struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"type"`
+		Slot int              `json:"slot"`
+		Type NamedAPIResource `json:"type"`
 	} `json:"types"`
-	Weight int `json:"weight"`
+	Weight int  `json:"weight"`
+	Stale  bool `json:"-"`
 }
 
-func getAPIEndpoint(path string, cache *cache.Cache) ([]byte, error) {
-	requestURL := fmt.Sprintf("%v%v", endpoint, path)
-
-	data, cacheObj := cache.Get(requestURL)
-
-	if cacheObj {
-		return data, nil
-
-	} else {
-		resp, err := http.Get(requestURL)
-
-		if err != nil {
-			return []byte{}, err
-		}
-
-		body, err := io.ReadAll(resp.Body)
-
-		if err != nil {
-			return []byte{}, err
-		}
-
-		resp.Body.Close()
-
-		if resp.StatusCode > 299 {
-			return []byte{}, errors.New(
-				fmt.Sprintf(
-					"Non 200 status code, got %v on path %v",
-					resp.StatusCode,
-					requestURL,
-				),
-			)
-		}
-
-		cache.Add(requestURL, body)
-
-		return body, nil
-	}
+type LearnedMove struct {
+	Name   string
+	Method string
+	Level  int
 }
 
-func GetLocations(offset string, cache *cache.Cache) (Locations, error) {
-	loc := Locations{}
-
-	path := fmt.Sprintf("location-area/?offset=%v", offset)
-
-	body, err := getAPIEndpoint(path, cache)
+func (c *Client) ExploreLocation(location string) (LocationData, error) {
+	return c.ExploreLocationContext(context.Background(), location)
+}
 
-	if err != nil {
-		return loc, err
-	}
+func (c *Client) ExploreLocationContext(ctx context.Context, location string) (LocationData, error) {
+	path := fmt.Sprintf("location-area/%v", location)
 
-	err = json.Unmarshal(body, &loc)
+	loc, stale, err := get[LocationData](ctx, c, path, locationTTL)
 
-	if err != nil {
-		return loc, err
-	}
+	loc.Stale = stale
 
-	return loc, nil
+	return loc, err
 }
 
-func ExploreLocation(location string, cache *cache.Cache) (LocationData, error) {
-	loc := LocationData{}
-
-	path := fmt.Sprintf("location-area/%v", location)
-
-	body, err := getAPIEndpoint(path, cache)
+func (c *Client) GetPokemon(name string) (Pokemon, error) {
+	return c.GetPokemonContext(context.Background(), name)
+}
 
-	if err != nil {
-		return loc, err
-	}
+func (c *Client) GetPokemonContext(ctx context.Context, name string) (Pokemon, error) {
+	path := fmt.Sprintf("pokemon/%v", name)
 
-	err = json.Unmarshal(body, &loc)
+	pokemon, stale, err := get[Pokemon](ctx, c, path, pokemonTTL)
 
-	if err != nil {
-		return loc, err
-	}
+	pokemon.Stale = stale
 
-	return loc, nil
+	return pokemon, err
 }
 
-func GetPokemon(name string, cache *cache.Cache) (Pokemon, error) {
-	pokemon := Pokemon{}
+func (p Pokemon) MovesLearnedIn(versionGroup string) []LearnedMove {
+	learned := []LearnedMove{}
 
-	path := fmt.Sprintf("pokemon/%v", name)
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name != versionGroup {
+				continue
+			}
 
-	body, err := getAPIEndpoint(path, cache)
+			learned = append(learned, LearnedMove{
+				Name:   move.Move.Name,
+				Method: detail.MoveLearnMethod.Name,
+				Level:  detail.LevelLearnedAt,
+			})
 
-	if err != nil {
-		return pokemon, err
-	}
-
-	err = json.Unmarshal(body, &pokemon)
-
-	if err != nil {
-		return pokemon, err
+			break
+		}
 	}
 
-	return pokemon, err
+	return learned
 }
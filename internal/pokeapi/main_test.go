@@ -0,0 +1,197 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/logan-bobo/pokedex-cli/internal/cache"
+)
+
+func pokemonBody() []byte {
+	moves := []string{}
+
+	for i := 0; i < 300; i++ {
+		moves = append(moves, fmt.Sprintf(
+			`{"move":{"name":"move-%v","url":"https://pokeapi.co/api/v2/move/%v/"},"version_group_details":[{"level_learned_at":%v,"move_learn_method":{"name":"level-up","url":"https://pokeapi.co/api/v2/move-learn-method/1/"},"version_group":{"name":"red-blue","url":"https://pokeapi.co/api/v2/version-group/1/"}}]}`,
+			i, i, i,
+		))
+	}
+
+	return []byte(fmt.Sprintf(
+		`{"name":"pikachu","base_experience":112,"height":4,"weight":60,"moves":[%v]}`,
+		strings.Join(moves, ","),
+	))
+}
+
+func TestGetPokemon(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.URL.Path != "/pokemon/pikachu" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write(pokemonBody())
+	}))
+	defer server.Close()
+
+	store := cache.NewCache(time.Minute)
+	defer store.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithStore(store))
+
+	for i := 0; i < 2; i++ {
+		pokemon, err := client.GetPokemon("pikachu")
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if pokemon.Name != "pikachu" || pokemon.BaseExperience != 112 || len(pokemon.Moves) != 300 {
+			t.Errorf("Unexpected pokemon. Got %v with %v moves", pokemon.Name, len(pokemon.Moves))
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected the second lookup to be served from the cache, got %v requests", requests)
+	}
+
+	if _, err := client.GetPokemon("missingno"); err == nil {
+		t.Errorf("Expected an error for an unknown pokemon")
+	}
+}
+
+func TestDecodeReusesCachedValue(t *testing.T) {
+	const path = "pokemon/pikachu"
+
+	store := cache.NewCache(time.Minute)
+	defer store.Close()
+
+	client := NewClient(WithStore(store))
+
+	store.Add(client.CacheKey(path), pokemonBody())
+
+	body, _ := store.Get(client.CacheKey(path))
+
+	first, err := decode[Pokemon](client, path, body)
+
+	if err != nil {
+		t.Fatalf("Unable to decode pokemon: %v", err)
+	}
+
+	second, err := decode[Pokemon](client, path, append([]byte{}, body...))
+
+	if err != nil {
+		t.Fatalf("Unable to decode pokemon: %v", err)
+	}
+
+	if first.Name != "pikachu" || len(second.Moves) != 300 {
+		t.Errorf("Unexpected pokemon. Got %v with %v moves", second.Name, len(second.Moves))
+	}
+
+	if &first.Moves[0] != &second.Moves[0] {
+		t.Errorf("Expected the second lookup to reuse the decoded value")
+	}
+}
+
+func BenchmarkDecodePokemon(b *testing.B) {
+	body := pokemonBody()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := decodeJSON[Pokemon](body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetPokemonCacheHit(b *testing.B) {
+	cases := []struct {
+		name       string
+		maxDecoded int
+	}{
+		{name: "reuse decoded value", maxDecoded: 32},
+		{name: "decode every hit", maxDecoded: 0},
+	}
+
+	for _, testCase := range cases {
+		b.Run(testCase.name, func(b *testing.B) {
+			store := cache.NewCache(time.Minute, cache.WithCompression(4<<10), cache.WithMaxDecoded(testCase.maxDecoded))
+			defer store.Close()
+
+			client := NewClient(WithStore(store))
+
+			store.AddWithTTL(client.CacheKey("pokemon/pikachu"), pokemonBody(), cache.NoExpiry)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := client.GetPokemon("pikachu"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestMovesLearnedIn(t *testing.T) {
+	var pokemon Pokemon
+
+	body := `{"moves":[
+		{"move":{"name":"thunder-shock"},"version_group_details":[
+			{"level_learned_at":1,"move_learn_method":{"name":"level-up"},"version_group":{"name":"red-blue"}},
+			{"level_learned_at":1,"move_learn_method":{"name":"level-up"},"version_group":{"name":"yellow"}}
+		]},
+		{"move":{"name":"thunderbolt"},"version_group_details":[
+			{"level_learned_at":0,"move_learn_method":{"name":"machine"},"version_group":{"name":"red-blue"}}
+		]},
+		{"move":{"name":"nuzzle"},"version_group_details":[
+			{"level_learned_at":1,"move_learn_method":{"name":"level-up"},"version_group":{"name":"x-y"}}
+		]}
+	]}`
+
+	if err := json.Unmarshal([]byte(body), &pokemon); err != nil {
+		t.Fatalf("Unable to decode pokemon: %v", err)
+	}
+
+	cases := []struct {
+		versionGroup string
+		expected     []LearnedMove
+	}{
+		{
+			versionGroup: "red-blue",
+			expected: []LearnedMove{
+				{Name: "thunder-shock", Method: "level-up", Level: 1},
+				{Name: "thunderbolt", Method: "machine"},
+			},
+		},
+		{
+			versionGroup: "x-y",
+			expected:     []LearnedMove{{Name: "nuzzle", Method: "level-up", Level: 1}},
+		},
+		{
+			versionGroup: "gold-silver",
+			expected:     []LearnedMove{},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.versionGroup, func(t *testing.T) {
+			if got := pokemon.MovesLearnedIn(testCase.versionGroup); !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("Moves did not match. Got %v wanted %v", got, testCase.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const maxMoveFetches = 8
+
+type VerboseEffect struct {
+	Effect      string           `json:"effect"`
+	ShortEffect string           `json:"short_effect"`
+	Language    NamedAPIResource `json:"language"`
+}
+
+type Move struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Accuracy      *int             `json:"accuracy"`
+	EffectChance  *int             `json:"effect_chance"`
+	PP            int              `json:"pp"`
+	Priority      int              `json:"priority"`
+	Power         *int             `json:"power"`
+	Type          NamedAPIResource `json:"type"`
+	DamageClass   NamedAPIResource `json:"damage_class"`
+	Target        NamedAPIResource `json:"target"`
+	Generation    NamedAPIResource `json:"generation"`
+	EffectEntries []VerboseEffect  `json:"effect_entries"`
+	Stale         bool             `json:"-"`
+}
+
+func (c *Client) GetMove(name string) (Move, error) {
+	return c.GetMoveContext(context.Background(), name)
+}
+
+func (c *Client) GetMoveContext(ctx context.Context, name string) (Move, error) {
+	path := fmt.Sprintf("move/%v", name)
+
+	move, stale, err := get[Move](ctx, c, path, resourceTTL)
+
+	move.Stale = stale
+
+	return move, err
+}
+
+func (c *Client) GetMovesContext(ctx context.Context, names ...string) ([]Move, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	moves := make([]Move, len(names))
+	slots := make(chan struct{}, maxMoveFetches)
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var failure error
+
+	fail := func(err error) {
+		once.Do(func() {
+			failure = err
+			cancel()
+		})
+	}
+
+	for i, name := range names {
+		wg.Add(1)
+
+		go func(i int, name string) {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				fail(ctx.Err())
+				return
+			}
+
+			defer func() { <-slots }()
+
+			move, err := c.GetMoveContext(ctx, name)
+
+			if err != nil {
+				fail(&MoveError{Name: name, Err: err})
+				return
+			}
+
+			moves[i] = move
+		}(i, name)
+	}
+
+	wg.Wait()
+
+	if failure != nil {
+		return nil, failure
+	}
+
+	return moves, nil
+}
+
+func (m Move) Effect(language string) string {
+	return effectText(m.EffectEntries, language, m.EffectChance)
+}
+
+func effectText(entries []VerboseEffect, language string, chance *int) string {
+	for _, entry := range entries {
+		if entry.Language.Name != language {
+			continue
+		}
+
+		effect := entry.ShortEffect
+
+		if effect == "" {
+			effect = entry.Effect
+		}
+
+		if chance != nil {
+			effect = strings.ReplaceAll(effect, "$effect_chance", fmt.Sprint(*chance))
+		}
+
+		return strings.Join(strings.Fields(effect), " ")
+	}
+
+	return ""
+}
@@ -0,0 +1,173 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetMove(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/move/thunderbolt":
+			w.Write([]byte(`{
+				"id": 85,
+				"name": "thunderbolt",
+				"accuracy": 100,
+				"effect_chance": 10,
+				"pp": 15,
+				"priority": 0,
+				"power": 90,
+				"type": {"name": "electric"},
+				"damage_class": {"name": "special"},
+				"effect_entries": [
+					{"effect": "Inflicts regular damage.", "short_effect": "Has a $effect_chance% chance to\nparalyze the target.", "language": {"name": "en"}}
+				]
+			}`))
+		case "/move/swords-dance":
+			w.Write([]byte(`{
+				"id": 14,
+				"name": "swords-dance",
+				"accuracy": null,
+				"effect_chance": null,
+				"pp": 20,
+				"priority": 0,
+				"power": null,
+				"type": {"name": "normal"},
+				"damage_class": {"name": "status"},
+				"effect_entries": [
+					{"effect": "Raises the user's Attack by two stages.", "short_effect": "", "language": {"name": "en"}}
+				]
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+
+	cases := []struct {
+		name     string
+		power    int
+		accuracy int
+		pp       int
+		class    string
+		effect   string
+	}{
+		{
+			name:     "thunderbolt",
+			power:    90,
+			accuracy: 100,
+			pp:       15,
+			class:    "special",
+			effect:   "Has a 10% chance to paralyze the target.",
+		},
+		{
+			name:   "swords-dance",
+			pp:     20,
+			class:  "status",
+			effect: "Raises the user's Attack by two stages.",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			move, err := client.GetMove(testCase.name)
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			power, accuracy := 0, 0
+
+			if move.Power != nil {
+				power = *move.Power
+			}
+
+			if move.Accuracy != nil {
+				accuracy = *move.Accuracy
+			}
+
+			if power != testCase.power || accuracy != testCase.accuracy || move.PP != testCase.pp || move.DamageClass.Name != testCase.class {
+				t.Errorf("Unexpected move: %+v", move)
+			}
+
+			if got := move.Effect("en"); got != testCase.effect {
+				t.Errorf("Effect did not match. Got %v wanted %v", got, testCase.effect)
+			}
+		})
+	}
+
+	if _, err := client.GetMove("struggle-bug-typo"); err == nil {
+		t.Errorf("Expected an error for an unknown move")
+	}
+}
+
+func TestGetMovesContext(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+
+		name := strings.TrimPrefix(r.URL.Path, "/move/")
+
+		if name == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprintf(w, `{"name":%q}`, name)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRateLimit(1000, 100))
+
+	names := []string{}
+
+	for i := 0; i < 20; i++ {
+		names = append(names, fmt.Sprintf("move-%v", i))
+	}
+
+	moves, err := client.GetMovesContext(context.Background(), names...)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i, move := range moves {
+		if move.Name != names[i] {
+			t.Errorf("Move did not match. Got %v wanted %v", move.Name, names[i])
+		}
+	}
+
+	if peak < 2 || peak > maxMoveFetches {
+		t.Errorf("Concurrent fetches did not match. Got %v wanted between 2 and %v", peak, maxMoveFetches)
+	}
+
+	_, err = client.GetMovesContext(context.Background(), "move-1", "missing", "move-2")
+
+	var moveErr *MoveError
+
+	if !errors.As(err, &moveErr) || moveErr.Name != "missing" || !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a not found error for the missing move, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+)
+
+const DefaultPageSize = 20
+
+const namesLimit = 100000
+
+type NamedAPIResourceList struct {
+	Count    int                `json:"count"`
+	Next     string             `json:"next"`
+	Previous string             `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
+	Stale    bool               `json:"-"`
+}
+
+func (c *Client) GetResourceList(resource string, offset int, limit int) (NamedAPIResourceList, error) {
+	return c.GetResourceListContext(context.Background(), resource, offset, limit)
+}
+
+func (c *Client) GetResourceListContext(ctx context.Context, resource string, offset int, limit int) (NamedAPIResourceList, error) {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	path := fmt.Sprintf("%v/?offset=%v&limit=%v", resource, max(offset, 0), limit)
+
+	list, stale, err := get[NamedAPIResourceList](ctx, c, path, listTTL)
+
+	list.Stale = stale
+
+	return list, err
+}
+
+func (c *Client) GetNamesContext(ctx context.Context, resource string) ([]string, error) {
+	list, err := c.GetResourceListContext(ctx, resource, 0, namesLimit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(list.Results))
+
+	for _, result := range list.Results {
+		names = append(names, result.Name)
+	}
+
+	return names, nil
+}
+
+type Pager struct {
+	client   *Client
+	resource string
+	start    int
+	limit    int
+	offset   int
+	started  bool
+	page     NamedAPIResourceList
+	err      error
+}
+
+func (c *Client) NewPager(resource string, offset int, limit int) *Pager {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	return &Pager{
+		client:   c,
+		resource: resource,
+		start:    max(offset, 0),
+		limit:    limit,
+	}
+}
+
+func (p *Pager) Next(ctx context.Context) bool {
+	p.err = nil
+
+	if !p.started {
+		return p.load(ctx, p.start)
+	}
+
+	if p.page.Next == "" {
+		return false
+	}
+
+	return p.load(ctx, p.offset+p.limit)
+}
+
+func (p *Pager) Prev(ctx context.Context) bool {
+	p.err = nil
+
+	if !p.started || p.offset == 0 {
+		return false
+	}
+
+	return p.load(ctx, max(p.offset-p.limit, 0))
+}
+
+func (p *Pager) load(ctx context.Context, offset int) bool {
+	page, err := p.client.GetResourceListContext(ctx, p.resource, offset, p.limit)
+
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.page = page
+	p.offset = offset
+	p.started = true
+
+	return true
+}
+
+func (p *Pager) Page() NamedAPIResourceList {
+	return p.page
+}
+
+func (p *Pager) Offset() int {
+	return p.offset
+}
+
+func (p *Pager) Err() error {
+	return p.err
+}
+
+func (p *Pager) Each(ctx context.Context, fn func(NamedAPIResource) error) error {
+	for p.Next(ctx) {
+		for _, result := range p.page.Results {
+			if err := fn(result); err != nil {
+				return err
+			}
+		}
+	}
+
+	return p.err
+}
@@ -0,0 +1,190 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/logan-bobo/pokedex-cli/internal/cache"
+)
+
+func listServer(t *testing.T, resource string, count int) (*httptest.Server, *int) {
+	requests := 0
+
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.URL.Path != "/"+resource+"/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		list := NamedAPIResourceList{Count: count}
+
+		for i := offset; i < count && i < offset+limit; i++ {
+			list.Results = append(list.Results, NamedAPIResource{
+				Name: fmt.Sprintf("%v-%v", resource, i),
+				URL:  fmt.Sprintf("%v/%v/%v/", server.URL, resource, i),
+			})
+		}
+
+		if offset+limit < count {
+			list.Next = fmt.Sprintf("%v/%v/?offset=%v&limit=%v", server.URL, resource, offset+limit, limit)
+		}
+
+		if offset > 0 {
+			list.Previous = fmt.Sprintf("%v/%v/?offset=%v&limit=%v", server.URL, resource, max(offset-limit, 0), limit)
+		}
+
+		json.NewEncoder(w).Encode(list)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestPagerNextAndPrev(t *testing.T) {
+	server, _ := listServer(t, "move", 45)
+
+	client := NewClient(WithBaseURL(server.URL))
+	pager := client.NewPager("move", 0, 20)
+	ctx := context.Background()
+
+	if pager.Prev(ctx) {
+		t.Errorf("Expected no previous page before the first page")
+	}
+
+	steps := []struct {
+		name   string
+		step   func(context.Context) bool
+		ok     bool
+		offset int
+		first  string
+		size   int
+	}{
+		{name: "first page", step: pager.Next, ok: true, offset: 0, first: "move-0", size: 20},
+		{name: "second page", step: pager.Next, ok: true, offset: 20, first: "move-20", size: 20},
+		{name: "last page", step: pager.Next, ok: true, offset: 40, first: "move-40", size: 5},
+		{name: "past the end", step: pager.Next, ok: false, offset: 40, first: "move-40", size: 5},
+		{name: "back a page", step: pager.Prev, ok: true, offset: 20, first: "move-20", size: 20},
+		{name: "back to the start", step: pager.Prev, ok: true, offset: 0, first: "move-0", size: 20},
+		{name: "before the start", step: pager.Prev, ok: false, offset: 0, first: "move-0", size: 20},
+	}
+
+	for _, step := range steps {
+		ok := step.step(ctx)
+
+		if err := pager.Err(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", step.name, err)
+		}
+
+		page := pager.Page()
+
+		if ok != step.ok || pager.Offset() != step.offset || len(page.Results) != step.size || page.Results[0].Name != step.first {
+			t.Errorf("%v: got ok %v offset %v with %v results starting %v", step.name, ok, pager.Offset(), len(page.Results), page.Results[0].Name)
+		}
+	}
+}
+
+func TestPagerEach(t *testing.T) {
+	cases := []struct {
+		name     string
+		offset   int
+		limit    int
+		expected int
+		requests int
+	}{
+		{name: "all pages", offset: 0, limit: 20, expected: 45, requests: 3},
+		{name: "single page", offset: 0, limit: 100, expected: 45, requests: 1},
+		{name: "starting offset", offset: 30, limit: 10, expected: 15, requests: 2},
+		{name: "default limit", offset: 0, limit: 0, expected: 45, requests: 3},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server, requests := listServer(t, "item", 45)
+
+			client := NewClient(WithBaseURL(server.URL))
+
+			names := []string{}
+
+			err := client.NewPager("item", testCase.offset, testCase.limit).Each(context.Background(), func(item NamedAPIResource) error {
+				names = append(names, item.Name)
+				return nil
+			})
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(names) != testCase.expected || names[0] != fmt.Sprintf("item-%v", testCase.offset) {
+				t.Errorf("Unexpected items. Got %v starting at %v, wanted %v", len(names), names[0], testCase.expected)
+			}
+
+			if *requests != testCase.requests {
+				t.Errorf("Request count did not match. Got %v wanted %v", *requests, testCase.requests)
+			}
+		})
+	}
+}
+
+func TestPagerEachStopsLazily(t *testing.T) {
+	server, requests := listServer(t, "pokemon", 100)
+
+	client := NewClient(WithBaseURL(server.URL))
+
+	stop := fmt.Errorf("stop")
+	seen := 0
+
+	err := client.NewPager("pokemon", 0, 10).Each(context.Background(), func(pokemon NamedAPIResource) error {
+		seen++
+
+		if seen == 15 {
+			return stop
+		}
+
+		return nil
+	})
+
+	if err != stop {
+		t.Errorf("Expected the callback error to be returned, got %v", err)
+	}
+
+	if *requests != 2 {
+		t.Errorf("Expected only the pages that were needed to be fetched, got %v requests", *requests)
+	}
+}
+
+func TestPagerUsesCache(t *testing.T) {
+	server, requests := listServer(t, "type", 30)
+
+	store := cache.NewCache(time.Minute)
+	defer store.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithStore(store))
+	pager := client.NewPager("type", 0, 20)
+	ctx := context.Background()
+
+	pager.Next(ctx)
+	pager.Next(ctx)
+	pager.Prev(ctx)
+
+	if *requests != 2 {
+		t.Errorf("Expected going back to be served from the cache, got %v requests", *requests)
+	}
+
+	if _, err := client.GetResourceList("missing", 0, 20); err == nil {
+		t.Errorf("Expected an error for an unknown resource")
+	}
+}
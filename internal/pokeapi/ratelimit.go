@@ -0,0 +1,84 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultRateLimit = 10
+
+const defaultBurst = 10
+
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.limiter = newLimiter(requestsPerSecond, burst)
+	}
+}
+
+func newLimiter(requestsPerSecond float64, burst int) *limiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	burst = max(burst, 1)
+
+	return &limiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+func (l *limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.After(l.last) {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+	}
+
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+func (l *limiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = min(l.burst, l.tokens+1)
+}
+
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	delay := l.reserve(time.Now())
+
+	if delay == 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, delay); err != nil {
+		l.cancel()
+		return err
+	}
+
+	return nil
+}
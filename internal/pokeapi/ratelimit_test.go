@@ -0,0 +1,108 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/logan-bobo/pokedex-cli/internal/cache"
+)
+
+func TestLimiterReserve(t *testing.T) {
+	start := time.Now()
+
+	l := newLimiter(10, 2)
+	l.last = start
+
+	cases := []struct {
+		name     string
+		at       time.Duration
+		expected time.Duration
+	}{
+		{name: "first burst token", at: 0, expected: 0},
+		{name: "second burst token", at: 0, expected: 0},
+		{name: "bucket empty", at: 0, expected: 100 * time.Millisecond},
+		{name: "queued behind the previous wait", at: 0, expected: 200 * time.Millisecond},
+		{name: "refilled after waiting", at: time.Second, expected: 0},
+	}
+
+	for _, testCase := range cases {
+		got := l.reserve(start.Add(testCase.at))
+
+		if got != testCase.expected {
+			t.Errorf("%v: delay did not match. Got %v wanted %v", testCase.name, got, testCase.expected)
+		}
+	}
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	if l := newLimiter(0, 10); l != nil {
+		t.Errorf("Expected a zero rate to disable the limiter")
+	}
+
+	var l *limiter
+
+	if err := l.wait(context.Background()); err != nil {
+		t.Errorf("Expected a nil limiter to never wait, got %v", err)
+	}
+}
+
+func TestRateLimitSkipsCacheHits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"pikachu"}`))
+	}))
+	defer server.Close()
+
+	store := cache.NewCache(time.Minute)
+	defer store.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithStore(store), WithRateLimit(0.001, 1))
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetPokemon("pikachu"); err != nil {
+			t.Fatalf("Expected cache hits to bypass the rate limit, got %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetPokemonContext(ctx, "bulbasaur")
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a network call to wait for the rate limit, got %v", err)
+	}
+}
+
+func TestRateLimitSharedAcrossGoroutines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"pikachu"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRateLimit(100, 2))
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			client.GetPokemon(string(rune('a' + i)))
+		}(i)
+	}
+
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("Expected 6 requests at 100rps with a burst of 2 to take at least 40ms, took %v", elapsed)
+	}
+}
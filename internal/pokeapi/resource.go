@@ -0,0 +1,85 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+func Resolve[T any](ctx context.Context, c *Client, resource NamedAPIResource) (T, error) {
+	var value T
+
+	path, err := c.resourceKey(resource)
+
+	if err != nil {
+		return value, err
+	}
+
+	value, _, err = get[T](ctx, c, path, resourceTTL)
+
+	return value, err
+}
+
+func (c *Client) resourceKey(resource NamedAPIResource) (string, error) {
+	path, err := c.resourcePath(resource.URL)
+
+	if err != nil {
+		return "", err
+	}
+
+	path = strings.TrimSuffix(path, "/")
+
+	if resource.Name == "" {
+		return path, nil
+	}
+
+	kind, _, _ := strings.Cut(path, "/")
+
+	return fmt.Sprintf("%v/%v", kind, resource.Name), nil
+}
+
+func (c *Client) resourcePath(resourceURL string) (string, error) {
+	if path, ok := strings.CutPrefix(resourceURL, c.baseURL); ok {
+		return path, nil
+	}
+
+	u, err := url.Parse(resourceURL)
+
+	if err != nil {
+		return "", err
+	}
+
+	_, path, ok := strings.Cut(u.Path, "/api/v2/")
+
+	if !ok || path == "" {
+		return "", errors.New(fmt.Sprintf("Resource URL %v is not a PokeAPI resource", resourceURL))
+	}
+
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+
+	return path, nil
+}
+
+func get[T any](ctx context.Context, c *Client, path string, ttl time.Duration) (T, bool, error) {
+	var value T
+
+	body, stale, err := c.getAPIEndpoint(ctx, path, ttl)
+
+	if err != nil {
+		return value, false, err
+	}
+
+	value, err = decode[T](c, path, body)
+
+	return value, stale, err
+}
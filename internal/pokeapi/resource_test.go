@@ -0,0 +1,151 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/logan-bobo/pokedex-cli/internal/cache"
+)
+
+func TestResourcePath(t *testing.T) {
+	client := NewClient(WithBaseURL("http://mirror.local/pokeapi/"))
+
+	cases := []struct {
+		name     string
+		url      string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "client base URL",
+			url:      "http://mirror.local/pokeapi/pokemon-species/25/",
+			expected: "pokemon-species/25/",
+		},
+		{
+			name:     "upstream URL",
+			url:      "https://pokeapi.co/api/v2/ability/9/",
+			expected: "ability/9/",
+		},
+		{
+			name:     "list URL keeps its query",
+			url:      "https://pokeapi.co/api/v2/move/?offset=20&limit=20",
+			expected: "move/?offset=20&limit=20",
+		},
+		{
+			name: "unrelated URL",
+			url:  "https://example.com/pokemon/25/",
+			err:  true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := client.resourcePath(testCase.url)
+
+			if (err != nil) != testCase.err {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if got != testCase.expected {
+				t.Errorf("Path did not match. Got %v wanted %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestResourceKey(t *testing.T) {
+	client := NewClient()
+
+	cases := []struct {
+		name     string
+		resource NamedAPIResource
+		expected string
+	}{
+		{
+			name:     "named resource uses its name",
+			resource: NamedAPIResource{Name: "pikachu", URL: "https://pokeapi.co/api/v2/pokemon-species/25/"},
+			expected: "pokemon-species/pikachu",
+		},
+		{
+			name:     "unnamed resource uses its ID",
+			resource: NamedAPIResource{URL: "https://pokeapi.co/api/v2/evolution-chain/10/"},
+			expected: "evolution-chain/10",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := client.resourceKey(testCase.resource)
+
+			if err != nil || got != testCase.expected {
+				t.Errorf("Key did not match. Got %v and %v wanted %v", got, err, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	requests := 0
+
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		switch r.URL.Path {
+		case "/pokemon/pikachu":
+			fmt.Fprintf(w, `{"name":"pikachu","species":{"name":"pikachu","url":"%v/pokemon-species/25/"}}`, server.URL)
+		case "/pokemon-species/pikachu":
+			w.Write([]byte(`{"name":"pikachu","capture_rate":190}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	store := cache.NewCache(time.Minute)
+	defer store.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithStore(store))
+
+	pokemon, err := client.GetPokemon("pikachu")
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	type species struct {
+		Name        string `json:"name"`
+		CaptureRate int    `json:"capture_rate"`
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := Resolve[species](context.Background(), client, pokemon.Species)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got.Name != "pikachu" || got.CaptureRate != 190 {
+			t.Errorf("Resolved species did not match. Got %v", got)
+		}
+	}
+
+	if _, err := client.GetPokemonSpecies("pikachu"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("Expected the resolved resource to be cached under its name, got %v requests", requests)
+	}
+
+	_, err = Resolve[species](context.Background(), client, NamedAPIResource{Name: "missingno", URL: server.URL + "/pokemon-species/0/"})
+
+	if err == nil {
+		t.Errorf("Expected an error resolving a missing resource")
+	}
+}
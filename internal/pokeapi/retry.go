@@ -0,0 +1,90 @@
+package pokeapi
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const defaultRetries = 2
+
+const defaultMinBackoff = 250 * time.Millisecond
+
+const defaultMaxBackoff = 5 * time.Second
+
+func WithRetries(retries int) ClientOption {
+	return func(c *Client) {
+		c.retries = retries
+	}
+}
+
+func WithBackoff(min, max time.Duration) ClientOption {
+	return func(c *Client) {
+		c.minBackoff = min
+		c.maxBackoff = max
+	}
+}
+
+func retryable(ctx context.Context, resp response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if resp.status == http.StatusTooManyRequests || resp.status > 499 {
+		return true
+	}
+
+	return err != nil && (resp.status == 0 || resp.status < 300)
+}
+
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+func (c *Client) backoff(attempt int, retryAfter time.Duration) (time.Duration, bool) {
+	if retryAfter > 0 {
+		return retryAfter, retryAfter <= c.maxBackoff
+	}
+
+	delay := c.minBackoff
+
+	for i := 1; i < attempt && delay < c.maxBackoff; i++ {
+		delay *= 2
+	}
+
+	delay = min(delay, c.maxBackoff)
+
+	if delay <= 0 {
+		return 0, true
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1)), true
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
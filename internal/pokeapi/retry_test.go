@@ -0,0 +1,193 @@
+package pokeapi
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	cases := []struct {
+		name       string
+		failures   int
+		status     int
+		retryAfter string
+		retries    int
+		requests   int
+		succeeds   bool
+	}{
+		{
+			name:     "server error then success",
+			failures: 2,
+			status:   http.StatusServiceUnavailable,
+			retries:  2,
+			requests: 3,
+			succeeds: true,
+		},
+		{
+			name:     "too many requests then success",
+			failures: 1,
+			status:   http.StatusTooManyRequests,
+			retries:  2,
+			requests: 2,
+			succeeds: true,
+		},
+		{
+			name:     "connection reset then success",
+			failures: 1,
+			retries:  2,
+			requests: 2,
+			succeeds: true,
+		},
+		{
+			name:     "retries exhausted",
+			failures: 5,
+			status:   http.StatusBadGateway,
+			retries:  2,
+			requests: 3,
+		},
+		{
+			name:     "not found is never retried",
+			failures: 5,
+			status:   http.StatusNotFound,
+			retries:  2,
+			requests: 1,
+		},
+		{
+			name:     "retries disabled",
+			failures: 1,
+			status:   http.StatusInternalServerError,
+			retries:  0,
+			requests: 1,
+		},
+		{
+			name:       "retry after longer than max backoff",
+			failures:   1,
+			status:     http.StatusTooManyRequests,
+			retryAfter: "120",
+			retries:    2,
+			requests:   1,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			requests := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+
+				if requests > testCase.failures {
+					w.Write([]byte(`{"name":"pikachu"}`))
+					return
+				}
+
+				if testCase.status == 0 {
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				}
+
+				if testCase.retryAfter != "" {
+					w.Header().Set("Retry-After", testCase.retryAfter)
+				}
+
+				w.WriteHeader(testCase.status)
+			}))
+			defer server.Close()
+
+			var debug bytes.Buffer
+
+			client := NewClient(
+				WithBaseURL(server.URL),
+				WithRetries(testCase.retries),
+				WithBackoff(time.Millisecond, 10*time.Millisecond),
+				WithDebug(&debug),
+			)
+
+			_, err := client.GetPokemon("pikachu")
+
+			if (err == nil) != testCase.succeeds {
+				t.Errorf("Unexpected result. Got error %v, wanted success %v", err, testCase.succeeds)
+			}
+
+			if requests != testCase.requests {
+				t.Errorf("Request count did not match. Got %v wanted %v", requests, testCase.requests)
+			}
+
+			if got := strings.Count(debug.String(), "attempt"); got != testCase.requests {
+				t.Errorf("Expected one debug line per attempt. Got %v wanted %v", got, testCase.requests)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{
+			name:     "missing",
+			expected: 0,
+		},
+		{
+			name:     "seconds",
+			value:    "3",
+			expected: 3 * time.Second,
+		},
+		{
+			name:     "http date",
+			value:    now.Add(time.Minute).Format(http.TimeFormat),
+			expected: time.Minute,
+		},
+		{
+			name:     "date in the past",
+			value:    now.Add(-time.Minute).Format(http.TimeFormat),
+			expected: 0,
+		},
+		{
+			name:     "garbage",
+			value:    "later",
+			expected: 0,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			header := http.Header{}
+
+			if testCase.value != "" {
+				header.Set("Retry-After", testCase.value)
+			}
+
+			got := parseRetryAfter(header, now)
+
+			if got != testCase.expected {
+				t.Errorf("Retry-After did not match. Got %v wanted %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestBackoffBounds(t *testing.T) {
+	client := NewClient(WithBackoff(100*time.Millisecond, time.Second))
+
+	for attempt := 1; attempt <= 6; attempt++ {
+		ceiling := min(100*time.Millisecond<<(attempt-1), time.Second)
+
+		for i := 0; i < 50; i++ {
+			delay, ok := client.backoff(attempt, 0)
+
+			if !ok || delay < ceiling/2 || delay > ceiling {
+				t.Fatalf("Backoff for attempt %v out of range. Got %v wanted between %v and %v", attempt, delay, ceiling/2, ceiling)
+			}
+		}
+	}
+}
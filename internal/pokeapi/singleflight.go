@@ -0,0 +1,83 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+)
+
+type call struct {
+	done    chan struct{}
+	resp    response
+	err     error
+	dups    int
+	waiters int
+	cancel  context.CancelFunc
+}
+
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) (response, error)) (response, error) {
+	g.mu.Lock()
+
+	if g.calls == nil {
+		g.calls = map[string]*call{}
+	}
+
+	c, ok := g.calls[key]
+
+	if ok {
+		c.dups++
+	} else {
+		fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+		c = &call{
+			done:   make(chan struct{}),
+			cancel: cancel,
+		}
+
+		g.calls[key] = c
+
+		go func() {
+			c.resp, c.err = fn(fetchCtx)
+			cancel()
+
+			g.mu.Lock()
+			g.forget(key, c)
+			g.mu.Unlock()
+
+			close(c.done)
+		}()
+	}
+
+	c.waiters++
+
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.resp, c.err
+
+	case <-ctx.Done():
+		g.mu.Lock()
+
+		c.waiters--
+
+		if c.waiters == 0 {
+			c.cancel()
+			g.forget(key, c)
+		}
+
+		g.mu.Unlock()
+
+		return response{}, ctx.Err()
+	}
+}
+
+func (g *flightGroup) forget(key string, c *call) {
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+}
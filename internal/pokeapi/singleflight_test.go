@@ -0,0 +1,150 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func waiting(group *flightGroup, key string) int {
+	group.mu.Lock()
+	defer group.mu.Unlock()
+
+	c, ok := group.calls[key]
+
+	if !ok {
+		return 0
+	}
+
+	return c.dups
+}
+
+func TestFlightGroupCoalesces(t *testing.T) {
+	const callers = 10
+	const key = "www.example.com"
+
+	var group flightGroup
+	var calls atomic.Int32
+	var finished sync.WaitGroup
+
+	release := make(chan struct{})
+
+	finished.Add(callers)
+
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer finished.Done()
+
+			resp, err := group.do(context.Background(), key, func(context.Context) (response, error) {
+				calls.Add(1)
+				<-release
+
+				return response{body: []byte("somedata"), status: 200}, nil
+			})
+
+			if err != nil || string(resp.body) != "somedata" {
+				t.Errorf("Unexpected result. Got %v and %v", string(resp.body), err)
+			}
+		}()
+	}
+
+	for waiting(&group, key) < callers-1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	close(release)
+	finished.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected one shared request, got %v", calls.Load())
+	}
+}
+
+func TestFlightGroupCancellation(t *testing.T) {
+	const key = "www.example.com"
+	const waiters = 2
+
+	cases := []struct {
+		name      string
+		cancelled int
+		aborted   bool
+	}{
+		{
+			name:      "one of two waiters cancels",
+			cancelled: 1,
+			aborted:   false,
+		},
+		{
+			name:      "every waiter cancels",
+			cancelled: 2,
+			aborted:   true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var group flightGroup
+
+			release := make(chan struct{})
+			aborted := make(chan bool, 1)
+
+			fn := func(ctx context.Context) (response, error) {
+				select {
+				case <-ctx.Done():
+					aborted <- true
+					return response{}, ctx.Err()
+				case <-release:
+					aborted <- false
+					return response{body: []byte("somedata")}, nil
+				}
+			}
+
+			cancels := []context.CancelFunc{}
+			results := []chan error{}
+
+			for i := 0; i < waiters; i++ {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				result := make(chan error, 1)
+
+				cancels = append(cancels, cancel)
+				results = append(results, result)
+
+				go func() {
+					_, err := group.do(ctx, key, fn)
+					result <- err
+				}()
+
+				for waiting(&group, key) < i {
+					time.Sleep(time.Millisecond)
+				}
+			}
+
+			for i := 0; i < testCase.cancelled; i++ {
+				cancels[i]()
+
+				if err := <-results[i]; !errors.Is(err, context.Canceled) {
+					t.Errorf("Waiter %v: expected cancellation, got %v", i, err)
+				}
+			}
+
+			if !testCase.aborted {
+				close(release)
+			}
+
+			if got := <-aborted; got != testCase.aborted {
+				t.Errorf("Expected aborted to be %v", testCase.aborted)
+			}
+
+			for i := testCase.cancelled; i < waiters; i++ {
+				if err := <-results[i]; err != nil {
+					t.Errorf("Waiter %v: unexpected error %v", i, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type APIResource struct {
+	URL string `json:"url"`
+}
+
+type Name struct {
+	Name     string           `json:"name"`
+	Language NamedAPIResource `json:"language"`
+}
+
+type FlavorText struct {
+	FlavorText string           `json:"flavor_text"`
+	Language   NamedAPIResource `json:"language"`
+	Version    NamedAPIResource `json:"version"`
+}
+
+type Genus struct {
+	Genus    string           `json:"genus"`
+	Language NamedAPIResource `json:"language"`
+}
+
+type PokemonSpecies struct {
+	ID                   int                `json:"id"`
+	Name                 string             `json:"name"`
+	Order                int                `json:"order"`
+	GenderRate           int                `json:"gender_rate"`
+	CaptureRate          int                `json:"capture_rate"`
+	BaseHappiness        int                `json:"base_happiness"`
+	IsBaby               bool               `json:"is_baby"`
+	IsLegendary          bool               `json:"is_legendary"`
+	IsMythical           bool               `json:"is_mythical"`
+	HatchCounter         int                `json:"hatch_counter"`
+	HasGenderDifferences bool               `json:"has_gender_differences"`
+	FormsSwitchable      bool               `json:"forms_switchable"`
+	GrowthRate           NamedAPIResource   `json:"growth_rate"`
+	EggGroups            []NamedAPIResource `json:"egg_groups"`
+	Color                NamedAPIResource   `json:"color"`
+	Shape                NamedAPIResource   `json:"shape"`
+	EvolvesFromSpecies   NamedAPIResource   `json:"evolves_from_species"`
+	EvolutionChain       APIResource        `json:"evolution_chain"`
+	Habitat              NamedAPIResource   `json:"habitat"`
+	Generation           NamedAPIResource   `json:"generation"`
+	Names                []Name             `json:"names"`
+	FlavorTextEntries    []FlavorText       `json:"flavor_text_entries"`
+	Genera               []Genus            `json:"genera"`
+	Varieties            []struct {
+		IsDefault bool             `json:"is_default"`
+		Pokemon   NamedAPIResource `json:"pokemon"`
+	} `json:"varieties"`
+	Stale bool `json:"-"`
+}
+
+func (c *Client) GetPokemonSpecies(name string) (PokemonSpecies, error) {
+	return c.GetPokemonSpeciesContext(context.Background(), name)
+}
+
+func (c *Client) GetPokemonSpeciesContext(ctx context.Context, name string) (PokemonSpecies, error) {
+	path := fmt.Sprintf("pokemon-species/%v", name)
+
+	species, stale, err := get[PokemonSpecies](ctx, c, path, pokemonTTL)
+
+	species.Stale = stale
+
+	return species, err
+}
+
+func (s PokemonSpecies) Genus(language string) string {
+	for _, genus := range s.Genera {
+		if genus.Language.Name == language {
+			return genus.Genus
+		}
+	}
+
+	return ""
+}
+
+func (s PokemonSpecies) FlavorText(version string, language string) string {
+	text := ""
+
+	for _, entry := range s.FlavorTextEntries {
+		if entry.Language.Name != language {
+			continue
+		}
+
+		text = entry.FlavorText
+
+		if entry.Version.Name == version {
+			break
+		}
+	}
+
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func (s PokemonSpecies) FemaleRatio() (float64, bool) {
+	if s.GenderRate < 0 {
+		return 0, false
+	}
+
+	return float64(s.GenderRate) / 8, true
+}
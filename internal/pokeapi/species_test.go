@@ -0,0 +1,119 @@
+package pokeapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const speciesBody = `{
+	"id": 25,
+	"name": "pikachu",
+	"gender_rate": 4,
+	"capture_rate": 190,
+	"base_happiness": 50,
+	"is_legendary": false,
+	"is_mythical": false,
+	"growth_rate": {"name": "medium", "url": "https://pokeapi.co/api/v2/growth-rate/2/"},
+	"egg_groups": [
+		{"name": "ground", "url": "https://pokeapi.co/api/v2/egg-group/5/"},
+		{"name": "fairy", "url": "https://pokeapi.co/api/v2/egg-group/6/"}
+	],
+	"evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/10/"},
+	"flavor_text_entries": [
+		{"flavor_text": "When several of\nthese POKéMON\fgather, their\nelectricity could\nbuild and cause\nlightning storms.", "language": {"name": "en"}, "version": {"name": "red"}},
+		{"flavor_text": "Il lui arrive de remettre en marche un Pokémon évanoui.", "language": {"name": "fr"}, "version": {"name": "x"}},
+		{"flavor_text": "It occasionally uses an electric shock to recharge a fellow Pikachu.", "language": {"name": "en"}, "version": {"name": "x"}}
+	],
+	"genera": [
+		{"genus": "Mouse Pokémon", "language": {"name": "en"}},
+		{"genus": "Pokémon Souris", "language": {"name": "fr"}}
+	]
+}`
+
+func TestGetPokemonSpecies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pokemon-species/pikachu" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write([]byte(speciesBody))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+
+	species, err := client.GetPokemonSpecies("pikachu")
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if species.CaptureRate != 190 || species.BaseHappiness != 50 || species.GrowthRate.Name != "medium" || len(species.EggGroups) != 2 {
+		t.Errorf("Unexpected species: %+v", species)
+	}
+
+	if species.EvolutionChain.URL != "https://pokeapi.co/api/v2/evolution-chain/10/" {
+		t.Errorf("Evolution chain did not match. Got %v", species.EvolutionChain.URL)
+	}
+
+	if ratio, ok := species.FemaleRatio(); !ok || ratio != 0.5 {
+		t.Errorf("Female ratio did not match. Got %v wanted 0.5", ratio)
+	}
+
+	cases := []struct {
+		name     string
+		version  string
+		language string
+		genus    string
+		flavor   string
+	}{
+		{
+			name:     "exact version",
+			version:  "red",
+			language: "en",
+			genus:    "Mouse Pokémon",
+			flavor:   "When several of these POKéMON gather, their electricity could build and cause lightning storms.",
+		},
+		{
+			name:     "unknown version falls back to the latest entry",
+			version:  "sword",
+			language: "en",
+			genus:    "Mouse Pokémon",
+			flavor:   "It occasionally uses an electric shock to recharge a fellow Pikachu.",
+		},
+		{
+			name:     "other language",
+			version:  "x",
+			language: "fr",
+			genus:    "Pokémon Souris",
+			flavor:   "Il lui arrive de remettre en marche un Pokémon évanoui.",
+		},
+		{
+			name:     "missing language",
+			version:  "red",
+			language: "de",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := species.Genus(testCase.language); got != testCase.genus {
+				t.Errorf("Genus did not match. Got %v wanted %v", got, testCase.genus)
+			}
+
+			if got := species.FlavorText(testCase.version, testCase.language); got != testCase.flavor {
+				t.Errorf("Flavor text did not match. Got %v wanted %v", got, testCase.flavor)
+			}
+		})
+	}
+}
+
+func TestFemaleRatioGenderless(t *testing.T) {
+	species := PokemonSpecies{GenderRate: -1}
+
+	if _, ok := species.FemaleRatio(); ok {
+		t.Errorf("Expected a genderless species to have no female ratio")
+	}
+}
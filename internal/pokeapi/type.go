@@ -0,0 +1,138 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+type TypeRelations struct {
+	NoDamageTo       []NamedAPIResource `json:"no_damage_to"`
+	HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`
+	DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`
+	NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`
+	HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`
+	DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"`
+}
+
+type Type struct {
+	ID              int              `json:"id"`
+	Name            string           `json:"name"`
+	DamageRelations TypeRelations    `json:"damage_relations"`
+	Generation      NamedAPIResource `json:"generation"`
+	MoveDamageClass NamedAPIResource `json:"move_damage_class"`
+	Pokemon         []struct {
+		Slot    int              `json:"slot"`
+		Pokemon NamedAPIResource `json:"pokemon"`
+	} `json:"pokemon"`
+	Moves []NamedAPIResource `json:"moves"`
+	Stale bool               `json:"-"`
+}
+
+type Matchup struct {
+	Type       string
+	Multiplier float64
+}
+
+type TypeChart struct {
+	multipliers map[string]map[string]float64
+}
+
+func (c *Client) GetType(name string) (Type, error) {
+	return c.GetTypeContext(context.Background(), name)
+}
+
+func (c *Client) GetTypeContext(ctx context.Context, name string) (Type, error) {
+	path := fmt.Sprintf("type/%v", name)
+
+	t, stale, err := get[Type](ctx, c, path, resourceTTL)
+
+	t.Stale = stale
+
+	return t, err
+}
+
+func (c *Client) GetTypeChartContext(ctx context.Context, names ...string) (TypeChart, error) {
+	types := []Type{}
+
+	for _, name := range names {
+		t, err := c.GetTypeContext(ctx, name)
+
+		if err != nil {
+			return TypeChart{}, err
+		}
+
+		types = append(types, t)
+	}
+
+	return NewTypeChart(types...), nil
+}
+
+func NewTypeChart(types ...Type) TypeChart {
+	chart := TypeChart{multipliers: map[string]map[string]float64{}}
+
+	for _, t := range types {
+		relations := t.DamageRelations
+
+		chart.setAll(t.Name, relations.DoubleDamageTo, 2, true)
+		chart.setAll(t.Name, relations.HalfDamageTo, 0.5, true)
+		chart.setAll(t.Name, relations.NoDamageTo, 0, true)
+		chart.setAll(t.Name, relations.DoubleDamageFrom, 2, false)
+		chart.setAll(t.Name, relations.HalfDamageFrom, 0.5, false)
+		chart.setAll(t.Name, relations.NoDamageFrom, 0, false)
+	}
+
+	return chart
+}
+
+func (c TypeChart) setAll(name string, others []NamedAPIResource, multiplier float64, attacking bool) {
+	for _, other := range others {
+		if attacking {
+			c.set(name, other.Name, multiplier)
+		} else {
+			c.set(other.Name, name, multiplier)
+		}
+	}
+}
+
+func (c TypeChart) set(attacking string, defending string, multiplier float64) {
+	if c.multipliers[attacking] == nil {
+		c.multipliers[attacking] = map[string]float64{}
+	}
+
+	c.multipliers[attacking][defending] = multiplier
+}
+
+func (c TypeChart) Effectiveness(attacking string, defending ...string) float64 {
+	multiplier := 1.0
+
+	for _, name := range defending {
+		if value, ok := c.multipliers[attacking][name]; ok {
+			multiplier *= value
+		}
+	}
+
+	return multiplier
+}
+
+func (c TypeChart) Defense(defending ...string) []Matchup {
+	matchups := []Matchup{}
+
+	for attacking := range c.multipliers {
+		multiplier := c.Effectiveness(attacking, defending...)
+
+		if multiplier != 1 {
+			matchups = append(matchups, Matchup{Type: attacking, Multiplier: multiplier})
+		}
+	}
+
+	sort.Slice(matchups, func(i, j int) bool {
+		if matchups[i].Multiplier != matchups[j].Multiplier {
+			return matchups[i].Multiplier > matchups[j].Multiplier
+		}
+
+		return matchups[i].Type < matchups[j].Type
+	})
+
+	return matchups
+}
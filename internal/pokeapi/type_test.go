@@ -0,0 +1,165 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func resources(names ...string) []NamedAPIResource {
+	list := []NamedAPIResource{}
+
+	for _, name := range names {
+		list = append(list, NamedAPIResource{Name: name})
+	}
+
+	return list
+}
+
+var testTypes = map[string]Type{
+	"water": {
+		Name: "water",
+		DamageRelations: TypeRelations{
+			DoubleDamageFrom: resources("electric", "grass"),
+			HalfDamageFrom:   resources("fire", "water", "ice", "steel"),
+		},
+	},
+	"ground": {
+		Name: "ground",
+		DamageRelations: TypeRelations{
+			DoubleDamageTo:   resources("fire", "electric", "poison", "rock", "steel"),
+			NoDamageTo:       resources("flying"),
+			DoubleDamageFrom: resources("water", "grass", "ice"),
+			HalfDamageFrom:   resources("poison", "rock"),
+			NoDamageFrom:     resources("electric"),
+		},
+	},
+	"flying": {
+		Name: "flying",
+		DamageRelations: TypeRelations{
+			DoubleDamageFrom: resources("electric", "ice", "rock"),
+			HalfDamageFrom:   resources("grass", "fighting", "bug"),
+			NoDamageFrom:     resources("ground"),
+		},
+	},
+}
+
+func TestTypeChartDefense(t *testing.T) {
+	cases := []struct {
+		name      string
+		defending []string
+		expected  []Matchup
+	}{
+		{
+			name:      "single type",
+			defending: []string{"water"},
+			expected: []Matchup{
+				{Type: "electric", Multiplier: 2},
+				{Type: "grass", Multiplier: 2},
+				{Type: "fire", Multiplier: 0.5},
+				{Type: "ice", Multiplier: 0.5},
+				{Type: "steel", Multiplier: 0.5},
+				{Type: "water", Multiplier: 0.5},
+			},
+		},
+		{
+			name:      "dual type multiplies and cancels out",
+			defending: []string{"water", "ground"},
+			expected: []Matchup{
+				{Type: "grass", Multiplier: 4},
+				{Type: "fire", Multiplier: 0.5},
+				{Type: "poison", Multiplier: 0.5},
+				{Type: "rock", Multiplier: 0.5},
+				{Type: "steel", Multiplier: 0.5},
+				{Type: "electric", Multiplier: 0},
+			},
+		},
+		{
+			name:      "immunity beats weakness",
+			defending: []string{"ground", "flying"},
+			expected: []Matchup{
+				{Type: "ice", Multiplier: 4},
+				{Type: "water", Multiplier: 2},
+				{Type: "bug", Multiplier: 0.5},
+				{Type: "fighting", Multiplier: 0.5},
+				{Type: "poison", Multiplier: 0.5},
+				{Type: "electric", Multiplier: 0},
+				{Type: "ground", Multiplier: 0},
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			types := []Type{}
+
+			for _, name := range testCase.defending {
+				types = append(types, testTypes[name])
+			}
+
+			got := NewTypeChart(types...).Defense(testCase.defending...)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("Matchups did not match. Got %v wanted %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestTypeChartEffectiveness(t *testing.T) {
+	chart := NewTypeChart(testTypes["ground"])
+
+	cases := []struct {
+		attacking string
+		defending []string
+		expected  float64
+	}{
+		{attacking: "ground", defending: []string{"fire"}, expected: 2},
+		{attacking: "ground", defending: []string{"fire", "rock"}, expected: 4},
+		{attacking: "ground", defending: []string{"flying", "steel"}, expected: 0},
+		{attacking: "ground", defending: []string{"normal"}, expected: 1},
+		{attacking: "electric", defending: []string{"ground"}, expected: 0},
+	}
+
+	for _, testCase := range cases {
+		got := chart.Effectiveness(testCase.attacking, testCase.defending...)
+
+		if got != testCase.expected {
+			t.Errorf("%v against %v did not match. Got %v wanted %v", testCase.attacking, testCase.defending, got, testCase.expected)
+		}
+	}
+}
+
+func TestGetTypeChart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		found, ok := testTypes[strings.TrimPrefix(r.URL.Path, "/type/")]
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		json.NewEncoder(w).Encode(found)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+
+	chart, err := client.GetTypeChartContext(context.Background(), "water", "ground")
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := chart.Effectiveness("grass", "water", "ground"); got != 4 {
+		t.Errorf("Effectiveness did not match. Got %v wanted 4", got)
+	}
+
+	if _, err := client.GetTypeChartContext(context.Background(), "water", "shadow"); err == nil {
+		t.Errorf("Expected an error for an unknown type")
+	}
+}
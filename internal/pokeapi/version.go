@@ -0,0 +1,27 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+)
+
+type Version struct {
+	ID           int              `json:"id"`
+	Name         string           `json:"name"`
+	VersionGroup NamedAPIResource `json:"version_group"`
+	Stale        bool             `json:"-"`
+}
+
+func (c *Client) GetVersion(name string) (Version, error) {
+	return c.GetVersionContext(context.Background(), name)
+}
+
+func (c *Client) GetVersionContext(ctx context.Context, name string) (Version, error) {
+	path := fmt.Sprintf("version/%v", name)
+
+	version, stale, err := get[Version](ctx, c, path, resourceTTL)
+
+	version.Stale = stale
+
+	return version, err
+}
@@ -2,12 +2,16 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"math/rand"
-	"net/url"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/logan-bobo/pokedex-cli/internal/cache"
@@ -30,16 +34,16 @@ type cliCommand struct {
 	name        string
 	description string
 	config      *config
-	callback    func(*config, *cache.Cache, *pokedex, string) error
+	callback    func(context.Context, *config, *pokeapi.Client, *pokedex, string) error
 }
 
 type config struct {
-	next     string
-	previous string
+	locations *pokeapi.Pager
+	version   string
+	language  string
 }
 
-func buildCommandInterface() map[string]cliCommand {
-	conf := config{}
+func buildCommandInterface(conf config) map[string]cliCommand {
 	return map[string]cliCommand{
 		"help": {
 			name:        "help",
@@ -79,7 +83,7 @@ func buildCommandInterface() map[string]cliCommand {
 		},
 		"inspect": {
 			name:        "inspect",
-			description: "Inspect a pokemon",
+			description: "Inspect a pokemon: inspect <name> [--moves]",
 			callback:    inspectPokemon,
 			config:      &conf,
 		},
@@ -89,120 +93,106 @@ func buildCommandInterface() map[string]cliCommand {
 			callback:    showPokedex,
 			config:      &conf,
 		},
+		"evolutions": {
+			name:        "evolutions",
+			description: "Show the evolution chain of a pokemon",
+			callback:    showEvolutions,
+			config:      &conf,
+		},
+		"move": {
+			name:        "move",
+			description: "Show the details of a move",
+			callback:    showMove,
+			config:      &conf,
+		},
+		"ability": {
+			name:        "ability",
+			description: "Show what an ability does and which pokemon have it",
+			callback:    showAbility,
+			config:      &conf,
+		},
+		"type": {
+			name:        "type",
+			description: "Show how a type deals and takes damage",
+			callback:    showType,
+			config:      &conf,
+		},
+		"cache": {
+			name:        "cache",
+			description: "Inspect the API cache: cache stats | cache list | cache clear [prefix] | cache export <file> | cache import <file>",
+			callback:    cacheCommand,
+			config:      &conf,
+		},
 	}
 }
 
-func commandExit(conf *config, cache *cache.Cache, pokedex *pokedex, location string) error {
+func commandExit(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, location string) error {
 	fmt.Println("Goodbye!")
+
+	if err := client.Store().Close(); err != nil {
+		fmt.Fprintln(os.Stderr, "closing cache:", err)
+	}
+
 	os.Exit(0)
 
 	return nil
 }
 
-func commandHelp(conf *config, cache *cache.Cache, pokedex *pokedex, location string) error {
+func commandHelp(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, location string) error {
 	fmt.Println("Welcome to the Pokedex!")
 
 	return nil
 }
 
-func mapNext(conf *config, cache *cache.Cache, pokedex *pokedex, location string) error {
-	var locations pokeapi.Locations
-	var err error
-
-	if conf.next == "" {
-		locations, err = pokeapi.GetLocations("0", cache)
-
-		if err != nil {
-			return err
-		}
-
-	} else {
-		u, err := url.Parse(conf.next)
-
-		if err != nil {
-			return err
-		}
-
-		query := u.Query()
-
-		offset, ok := query["offset"]
+func mapNext(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, location string) error {
+	if conf.locations == nil {
+		conf.locations = client.NewPager("location-area", 0, pokeapi.DefaultPageSize)
+	}
 
-		if !ok {
-			return errors.New(fmt.Sprintf("Offset not found in URL: %v", conf.next))
+	if !conf.locations.Next(ctx) {
+		if err := conf.locations.Err(); err != nil {
+			return lookupError(ctx, client, err, "location-area", "")
 		}
 
-		locations, err = pokeapi.GetLocations(offset[0], cache)
+		fmt.Println("No more locations...")
 
-		if err != nil {
-			return err
-		}
+		return nil
 	}
 
-	for _, location := range locations.Results {
-		fmt.Println(location.Name)
-	}
-
-	conf.next = locations.Next
-
-	previous, ok := locations.Previous.(string)
-
-	if ok {
-		conf.previous = previous
-	}
+	printLocations(conf.locations.Page())
 
 	return nil
 }
 
-func mapPrevious(conf *config, cache *cache.Cache, pokedex *pokedex, location string) error {
-	var locations pokeapi.Locations
-	var err error
-
-	if conf.previous == "" {
-		fmt.Println("No location to go back to...")
-		return err
-
-	} else {
-		u, err := url.Parse(conf.previous)
-
-		if err != nil {
-			return err
+func mapPrevious(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, location string) error {
+	if conf.locations == nil || !conf.locations.Prev(ctx) {
+		if conf.locations != nil && conf.locations.Err() != nil {
+			return lookupError(ctx, client, conf.locations.Err(), "location-area", "")
 		}
 
-		query := u.Query()
-
-		offset, ok := query["offset"]
+		fmt.Println("No location to go back to...")
 
-		if !ok {
-			return errors.New(fmt.Sprintf("Offset not in URL: %v", conf.previous))
-		}
+		return nil
+	}
 
-		locations, err = pokeapi.GetLocations(offset[0], cache)
+	printLocations(conf.locations.Page())
 
-		if err != nil {
-			return err
-		}
-	}
+	return nil
+}
 
+func printLocations(locations pokeapi.NamedAPIResourceList) {
 	for _, location := range locations.Results {
 		fmt.Println(location.Name)
 	}
 
-	previous, ok := locations.Previous.(string)
-
-	if ok {
-		conf.previous = previous
-	}
-
-	conf.next = locations.Next
-
-	return nil
+	printStale(locations.Stale)
 }
 
-func exploreLocation(conf *config, cache *cache.Cache, pokedex *pokedex, location string) error {
-	locations, err := pokeapi.ExploreLocation(location, cache)
+func exploreLocation(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, location string) error {
+	locations, err := client.ExploreLocationContext(ctx, location)
 
 	if err != nil {
-		return err
+		return lookupError(ctx, client, err, "location-area", location)
 	}
 
 	fmt.Println("Found Pokemon...")
@@ -210,18 +200,22 @@ func exploreLocation(conf *config, cache *cache.Cache, pokedex *pokedex, locatio
 		fmt.Printf("- %v \n", pokemon.Pokemon.Name)
 	}
 
+	printStale(locations.Stale)
+
 	return nil
 }
 
-func catchPokemon(conf *config, cache *cache.Cache, pokedex *pokedex, name string) error {
+func catchPokemon(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, name string) error {
 	catch := false
 
-	pokemon, err := pokeapi.GetPokemon(name, cache)
+	pokemon, err := client.GetPokemonContext(ctx, name)
 
 	if err != nil {
-		return err
+		return lookupError(ctx, client, err, "pokemon", name)
 	}
 
+	printStale(pokemon.Stale)
+
 	rand := rand.New(rand.NewSource(time.Now().UnixNano()))
 
 	roll := rand.Intn(100)
@@ -268,7 +262,9 @@ func catchPokemon(conf *config, cache *cache.Cache, pokedex *pokedex, name strin
 	return nil
 }
 
-func inspectPokemon(conf *config, cache *cache.Cache, pokedex *pokedex, name string) error {
+func inspectPokemon(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, args string) error {
+	name, options := parseArgs(args)
+
 	pokemon, ok := pokedex.entities[name]
 
 	if !ok {
@@ -287,12 +283,284 @@ func inspectPokemon(conf *config, cache *cache.Cache, pokedex *pokedex, name str
 		for _, item := range pokemon.Types {
 			fmt.Printf("   - %v \n", item.Type.Name)
 		}
+
+		types := []string{}
+
+		for _, item := range pokemon.Types {
+			types = append(types, item.Type.Name)
+		}
+
+		chart, err := client.GetTypeChartContext(ctx, types...)
+
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
+
+		if err != nil {
+			fmt.Printf("Type matchups unavailable: %v \n", err)
+		} else {
+			printMatchups(chart.Defense(types...))
+		}
+
+		fmt.Println("Abilities:")
+
+		for _, item := range pokemon.Abilities {
+			fmt.Printf("   - %v \n", abilityName(item.Ability.Name, item.IsHidden))
+		}
+
+		species, err := client.GetPokemonSpeciesContext(ctx, pokemon.Species.Name)
+
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
+
+		if err != nil {
+			fmt.Printf("Species info unavailable: %v \n", err)
+		} else {
+			if genus := species.Genus(conf.language); genus != "" {
+				fmt.Printf("Genus: %v \n", genus)
+			}
+
+			if flavor := species.FlavorText(conf.version, conf.language); flavor != "" {
+				fmt.Printf("Pokedex entry: %v \n", flavor)
+			}
+
+			printStale(species.Stale)
+		}
+
+		if options["--moves"] {
+			version, err := client.GetVersionContext(ctx, conf.version)
+
+			if err != nil {
+				return lookupError(ctx, client, err, "version", conf.version)
+			}
+
+			learned := pokemon.MovesLearnedIn(version.VersionGroup.Name)
+			names := []string{}
+
+			for _, item := range learned {
+				names = append(names, item.Name)
+			}
+
+			moves, err := client.GetMovesContext(ctx, names...)
+
+			var moveErr *pokeapi.MoveError
+
+			if errors.As(err, &moveErr) {
+				return lookupError(ctx, client, moveErr.Err, "move", moveErr.Name)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Moves in %v:\n", version.Name)
+
+			if len(moves) == 0 {
+				fmt.Println("   - none")
+			}
+
+			for i, move := range moves {
+				fmt.Printf("   - %v: %v \n", learnedBy(learned[i]), describeMove(move))
+			}
+		}
+	}
+
+	return nil
+}
+
+func showMove(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, name string) error {
+	move, err := client.GetMoveContext(ctx, name)
+
+	if err != nil {
+		return lookupError(ctx, client, err, "move", name)
+	}
+
+	fmt.Printf("Name: %v \n Type: %v \n Damage class: %v \n Power: %v \n Accuracy: %v \n PP: %v \n Priority: %v \n",
+		move.Name, move.Type.Name, move.DamageClass.Name, optional(move.Power), optional(move.Accuracy), move.PP, move.Priority,
+	)
+
+	if effect := move.Effect(conf.language); effect != "" {
+		fmt.Printf("Effect: %v \n", effect)
 	}
 
+	printStale(move.Stale)
+
 	return nil
 }
 
-func showPokedex(conf *config, cahce *cache.Cache, pokedex *pokedex, name string) error {
+func showType(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, name string) error {
+	found, err := client.GetTypeContext(ctx, name)
+
+	if err != nil {
+		return lookupError(ctx, client, err, "type", name)
+	}
+
+	relations := found.DamageRelations
+
+	fmt.Printf("Name: %v \n", found.Name)
+	fmt.Printf(" Double damage to: %v \n", resourceNames(relations.DoubleDamageTo))
+	fmt.Printf(" Half damage to: %v \n", resourceNames(relations.HalfDamageTo))
+	fmt.Printf(" No damage to: %v \n", resourceNames(relations.NoDamageTo))
+	fmt.Printf(" Double damage from: %v \n", resourceNames(relations.DoubleDamageFrom))
+	fmt.Printf(" Half damage from: %v \n", resourceNames(relations.HalfDamageFrom))
+	fmt.Printf(" No damage from: %v \n", resourceNames(relations.NoDamageFrom))
+
+	printStale(found.Stale)
+
+	return nil
+}
+
+func printMatchups(matchups []pokeapi.Matchup) {
+	weak, resists, immune := []string{}, []string{}, []string{}
+
+	for _, matchup := range matchups {
+		switch {
+		case matchup.Multiplier == 0:
+			immune = append(immune, matchup.Type)
+		case matchup.Multiplier > 1:
+			weak = append(weak, fmt.Sprintf("%v (x%v)", matchup.Type, matchup.Multiplier))
+		default:
+			resists = append(resists, fmt.Sprintf("%v (x%v)", matchup.Type, matchup.Multiplier))
+		}
+	}
+
+	fmt.Printf("Weak to: %v \n", listOrNone(weak))
+	fmt.Printf("Resists: %v \n", listOrNone(resists))
+	fmt.Printf("Immune to: %v \n", listOrNone(immune))
+}
+
+func resourceNames(resources []pokeapi.NamedAPIResource) string {
+	names := []string{}
+
+	for _, resource := range resources {
+		names = append(names, resource.Name)
+	}
+
+	return listOrNone(names)
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+
+	return strings.Join(items, ", ")
+}
+
+func showAbility(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, name string) error {
+	ability, err := client.GetAbilityContext(ctx, name)
+
+	if err != nil {
+		return lookupError(ctx, client, err, "ability", name)
+	}
+
+	fmt.Printf("Name: %v \n", ability.Name)
+
+	if effect := ability.Effect(conf.language); effect != "" {
+		fmt.Printf("Effect: %v \n", effect)
+	}
+
+	fmt.Println("Pokemon:")
+
+	for _, item := range ability.Pokemon {
+		fmt.Printf("   - %v \n", abilityName(item.Pokemon.Name, item.IsHidden))
+	}
+
+	printStale(ability.Stale)
+
+	return nil
+}
+
+func abilityName(name string, hidden bool) string {
+	if hidden {
+		return fmt.Sprintf("%v (hidden)", name)
+	}
+
+	return name
+}
+
+func describeMove(move pokeapi.Move) string {
+	return fmt.Sprintf("%v (%v, %v) power %v, accuracy %v, pp %v",
+		move.Name, move.Type.Name, move.DamageClass.Name, optional(move.Power), optional(move.Accuracy), move.PP,
+	)
+}
+
+func learnedBy(move pokeapi.LearnedMove) string {
+	if move.Method == "level-up" {
+		return fmt.Sprintf("level %v", move.Level)
+	}
+
+	return move.Method
+}
+
+func optional(value *int) string {
+	if value == nil {
+		return "-"
+	}
+
+	return fmt.Sprint(*value)
+}
+
+func parseArgs(args string) (string, map[string]bool) {
+	name := ""
+	options := map[string]bool{}
+
+	for _, field := range strings.Fields(args) {
+		if strings.HasPrefix(field, "--") {
+			options[field] = true
+		} else if name == "" {
+			name = field
+		}
+	}
+
+	return name, options
+}
+
+func showEvolutions(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, name string) error {
+	species := name
+
+	chain, err := client.GetSpeciesEvolutionChainContext(ctx, species)
+
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		pokemon, pokemonErr := client.GetPokemonContext(ctx, name)
+
+		if pokemonErr == nil {
+			species = pokemon.Species.Name
+			chain, err = client.GetSpeciesEvolutionChainContext(ctx, species)
+		}
+	}
+
+	if errors.Is(err, pokeapi.ErrNoEvolutionChain) {
+		fmt.Printf("%v does not evolve \n", species)
+		return nil
+	}
+
+	if err != nil {
+		return lookupError(ctx, client, err, "pokemon-species", species)
+	}
+
+	printEvolution(chain.Chain, 0)
+	printStale(chain.Stale)
+
+	return nil
+}
+
+func printEvolution(link pokeapi.ChainLink, depth int) {
+	indent := strings.Repeat("   ", depth)
+
+	if trigger := link.Trigger(); trigger != "" {
+		fmt.Printf("%v- %v (%v) \n", indent, link.Species.Name, trigger)
+	} else {
+		fmt.Printf("%v- %v \n", indent, link.Species.Name)
+	}
+
+	for _, next := range link.EvolvesTo {
+		printEvolution(next, depth+1)
+	}
+}
+
+func showPokedex(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, name string) error {
 	fmt.Println("Listing Pokemon: ")
 
 	for pokemon, _ := range pokedex.entities {
@@ -302,24 +570,246 @@ func showPokedex(conf *config, cahce *cache.Cache, pokedex *pokedex, name string
 	return nil
 }
 
+func cacheCommand(ctx context.Context, conf *config, client *pokeapi.Client, pokedex *pokedex, args string) error {
+	store := client.Store()
+	fields := strings.Fields(args)
+
+	if len(fields) == 0 {
+		return errors.New("Usage: cache stats | cache list | cache clear [prefix] | cache export <file> | cache import <file>")
+	}
+
+	switch fields[0] {
+	case "stats":
+		stats := store.Stats()
+
+		fmt.Printf("Stored entries: %v \n Stored bytes: %v \n Bytes saved by compression: %v \n Hits: %v \n Stale hits: %v \n Misses: %v \n Evictions: %v \n Expirations: %v \n Write errors: %v \n",
+			stats.Entries, stats.Bytes, stats.BytesSaved, stats.Hits, stats.StaleHits, stats.Misses, stats.Evictions, stats.Expirations, stats.WriteErrors,
+		)
+
+	case "list":
+		fmt.Println("Cached responses: ")
+
+		for _, key := range cache.Keys(store) {
+			fmt.Printf("- %v \n", key)
+		}
+
+	case "clear":
+		prefix := ""
+
+		if len(fields) > 1 {
+			prefix = fields[1]
+
+			if !strings.HasPrefix(prefix, "http") {
+				prefix = client.CacheKey(prefix)
+			}
+		}
+
+		fmt.Printf("Removed %v cached responses \n", cache.Clear(store, prefix))
+
+	case "export":
+		if len(fields) < 2 {
+			return errors.New("Usage: cache export <file>")
+		}
+
+		return exportCache(store, fields[1])
+
+	case "import":
+		if len(fields) < 2 {
+			return errors.New("Usage: cache import <file>")
+		}
+
+		return importCache(store, fields[1])
+
+	default:
+		return errors.New(fmt.Sprintf("Unknown cache command: %v", fields[0]))
+	}
+
+	return nil
+}
+
+func exportCache(store cache.Store, path string) error {
+	file, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := cache.Export(store, file)
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %v cached responses to %v \n", count, path)
+
+	return nil
+}
+
+func importCache(store cache.Store, path string) error {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	count, err := cache.Import(store, file)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %v cached responses from %v \n", count, path)
+
+	return nil
+}
+
+type interrupter struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+func (i *interrupter) start() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	i.mu.Lock()
+	i.cancel = cancel
+	i.mu.Unlock()
+
+	return ctx, func() {
+		i.mu.Lock()
+		i.cancel = nil
+		i.mu.Unlock()
+
+		cancel()
+	}
+}
+
+func (i *interrupter) listen(signals <-chan os.Signal) {
+	for range signals {
+		i.mu.Lock()
+
+		if i.cancel != nil {
+			i.cancel()
+			i.cancel = nil
+		} else {
+			fmt.Print("\nPokedex -> ")
+		}
+
+		i.mu.Unlock()
+	}
+}
+
+func printStale(stale bool) {
+	if stale {
+		fmt.Println("(showing stale cached data)")
+	}
+}
+
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "pokedex-cli")
+}
+
+func newCache(dir string, opts ...cache.Option) cache.Store {
+	const ttl = 60 * time.Second
+
+	if dir == "" {
+		return cache.NewCache(ttl, opts...)
+	}
+
+	c, err := cache.NewPersistentCache(ttl, dir, opts...)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to open cache directory, falling back to memory:", err)
+		return cache.NewCache(ttl, opts...)
+	}
+
+	return c
+}
+
 func main() {
-	cliCommands := buildCommandInterface()
+	cacheDir := flag.String("cache-dir", defaultCacheDir(), "directory to persist API responses in, empty to keep them in memory only")
+	cacheMaxBytes := flag.Int("cache-max-bytes", 64<<20, "maximum size of cached API responses in bytes, 0 for no limit")
+	cacheMaxEntries := flag.Int("cache-max-entries", 1000, "maximum number of cached API responses, 0 for no limit")
+	cacheMaxDecoded := flag.Int("cache-max-decoded", 32, "maximum number of decoded API responses kept in memory, 0 to decode on every lookup")
+	cacheCompressAbove := flag.Int("cache-compress-above", 4<<10, "compress cached API responses larger than this many bytes, 0 to disable")
+	apiURL := flag.String("api-url", pokeapi.DefaultBaseURL, "base URL of the PokeAPI instance to query")
+	apiTimeout := flag.Duration("api-timeout", 30*time.Second, "timeout for a single PokeAPI request, 0 for none")
+	apiRetries := flag.Int("api-retries", 2, "how many times to retry a PokeAPI request that failed with a transient error")
+	apiRate := flag.Float64("api-rate", 10, "maximum PokeAPI requests per second, 0 for no limit")
+	apiBurst := flag.Int("api-burst", 10, "how many PokeAPI requests may be sent at once before the rate limit applies")
+	debug := flag.Bool("debug", false, "print every PokeAPI request attempt to standard error")
+	gameVersion := flag.String("game-version", "red", "game version to show pokedex entries and moves from")
+	language := flag.String("language", "en", "language to show pokedex entries in")
+	cacheReapInterval := flag.Duration("cache-reap-interval", 5*time.Second, "how often expired API responses are swept from the cache")
+	flag.Parse()
+
+	cliCommands := buildCommandInterface(config{
+		version:  *gameVersion,
+		language: *language,
+	})
 
 	scanner := bufio.NewScanner(os.Stdin)
 
-	cache := cache.NewCache(60 * time.Second)
+	cache := newCache(
+		*cacheDir,
+		cache.WithMaxBytes(*cacheMaxBytes),
+		cache.WithMaxEntries(*cacheMaxEntries),
+		cache.WithMaxDecoded(*cacheMaxDecoded),
+		cache.WithReapInterval(*cacheReapInterval),
+		cache.WithCompression(*cacheCompressAbove),
+		cache.WithStaleWhileRevalidate(24*time.Hour),
+		cache.WithStaleIfError(7*24*time.Hour),
+	)
+
+	clientOpts := []pokeapi.ClientOption{
+		pokeapi.WithBaseURL(*apiURL),
+		pokeapi.WithTimeout(*apiTimeout),
+		pokeapi.WithRetries(*apiRetries),
+		pokeapi.WithRateLimit(*apiRate, *apiBurst),
+		pokeapi.WithStore(cache),
+	}
+
+	if *debug {
+		clientOpts = append(clientOpts, pokeapi.WithDebug(os.Stderr))
+	}
+
+	client := pokeapi.NewClient(clientOpts...)
 
 	pokedex := newPokedex()
 
+	interrupts := &interrupter{}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+
+	go interrupts.listen(signals)
+
 	for {
 		fmt.Print("Pokedex -> ")
 
-		scanner.Scan()
+		more := scanner.Scan()
 
 		if err := scanner.Err(); err != nil {
 			fmt.Fprintln(os.Stderr, "reading standard input:", err)
 		}
 
+		if !more {
+			fmt.Println()
+			commandExit(context.Background(), nil, client, pokedex, "")
+		}
+
 		inputRaw := scanner.Text()
 
 		inputSplit := strings.Split(inputRaw, " ")
@@ -331,10 +821,16 @@ func main() {
 			continue
 		}
 
-		if len(inputSplit) == 1 {
-			command.callback(command.config, cache, pokedex, "")
-		} else {
-			command.callback(command.config, cache, pokedex, inputSplit[1])
+		ctx, done := interrupts.start()
+
+		err := command.callback(ctx, command.config, client, pokedex, strings.Join(inputSplit[1:], " "))
+
+		done()
+
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Cancelled")
+		} else if err != nil {
+			fmt.Println(err)
 		}
 	}
 }